@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"sca/internal/service"
+)
+
+func runImport(args []string) {
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	configPath := flags.String("config", "configs/stub.toml", "path to config file")
+	entity := flags.String("entity", "", "entity to import: cats, missions or targets")
+	format := flags.String("format", "", "input format: csv, json or ndjson (defaults to the file extension)")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: sca import -entity <cats|missions|targets> [-format <csv|json|ndjson>] [-config path] <file|->")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 || *entity == "" {
+		flags.Usage()
+		os.Exit(2)
+	}
+	path := flags.Arg(0)
+
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	switch service.TransferFormat(*format) {
+	case service.FormatCSV, service.FormatJSON, service.FormatNDJSON:
+	default:
+		log.Fatalf("unsupported import format %q", *format)
+	}
+	switch service.TransferEntity(*entity) {
+	case service.TransferCats, service.TransferMissions, service.TransferTargets:
+	default:
+		log.Fatalf("unsupported import entity %q", *entity)
+	}
+
+	var input io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer file.Close()
+		input = file
+	}
+
+	a, err := bootstrap(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	result, err := a.services.Transfer.Import(ctx, service.ImportInput{
+		Entity: service.TransferEntity(*entity),
+		Format: service.TransferFormat(*format),
+		Reader: input,
+	})
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	for _, e := range result.Errors {
+		if e.ID != nil {
+			fmt.Fprintf(os.Stderr, "row %d (%s): %s\n", e.Row, e.ID, e.Error)
+		} else {
+			fmt.Fprintf(os.Stderr, "row %d: %s\n", e.Row, e.Error)
+		}
+	}
+	fmt.Printf("Imported %s: %d created, %d skipped, %d failed\n", result.Entity, result.Created, result.Skipped, result.Failed)
+
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -1,17 +1,27 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
 	"time"
 
 	"sca/internal/config"
 	"sca/internal/handler"
 	"sca/internal/service"
 	"sca/internal/storage"
+	"sca/pkg/blob"
 	"sca/pkg/cache"
 	"sca/pkg/database/mysql"
 	"sca/pkg/errors"
+	"sca/pkg/notify"
 	pkgvalidator "sca/pkg/validator"
 
 	"github.com/go-playground/validator/v10"
@@ -21,18 +31,46 @@ import (
 	"github.com/gofiber/fiber/v3/middleware/logger"
 )
 
+const multipartOverhead = 1 << 20
+
 func main() {
-	configPath := flag.String("config", "configs/stub.toml", "path to config file")
-	flag.Parse()
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		serve(args)
+	case "import":
+		runImport(args)
+	case "seed":
+		runSeed(args)
+	case "backup":
+		runBackup(args)
+	case "restore":
+		runRestore(args)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\nusage: sca [serve|import|seed|backup|restore] [flags]\n", command)
+		os.Exit(2)
+	}
+}
 
-	conf, err := config.Load(*configPath)
+type application struct {
+	conf      *config.Config
+	services  *service.Service
+	validator *pkgvalidator.StructValidator
+}
+
+func bootstrap(configPath string) (*application, error) {
+	conf, err := config.Load(configPath)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	db, err := mysql.Connect(conf.Mysql.Username, conf.Mysql.Password, conf.Mysql.Host, conf.Mysql.Database)
 	if err != nil {
-		log.Fatalf("Error connecting to database: %v", err)
+		return nil, fmt.Errorf("error connecting to database: %v", err)
 	}
 
 	redisCache := cache.NewRedisCache(cache.Options{
@@ -44,19 +82,85 @@ func main() {
 	v := validator.New(validator.WithRequiredStructEnabled())
 	pkgvalidator.RegisterValidators(v)
 	pkgvalidator.InitBreedValidator(redisCache, conf.Breeds.Url, "breeds", time.Hour)
+	structValidator := pkgvalidator.NewStructValidator(v)
 
 	store := storage.NewStorage(db)
 
+	var notifier notify.Notifier = notify.NewLogNotifier()
+	if conf.Notify.WebhookUrl != "" {
+		notifier = notify.NewWebhookNotifier(conf.Notify.WebhookUrl)
+	}
+
+	blobs, err := newBlobStore(conf)
+	if err != nil {
+		return nil, fmt.Errorf("error initialising blob store: %v", err)
+	}
+
 	s := service.NewService(&service.Depends{
-		Storage: store,
-		Cache:   redisCache,
+		Storage:  store,
+		Cache:    redisCache,
+		Notifier: notifier,
+		Scoring:  service.ScoringModel(conf.Recommend),
+		Blobs:    blobs,
+		Attachments: service.AttachmentOptions{
+			MaxSize:      conf.Attachments.MaxSize,
+			AllowedTypes: conf.Attachments.AllowedTypes,
+			SigningKey:   conf.Attachments.SigningKey,
+			DownloadTTL:  conf.Attachments.DownloadTTL,
+		},
+		Comments: service.CommentOptions{
+			EditWindow: conf.Comments.EditWindow,
+		},
+		Debriefs: service.DebriefOptions{
+			Required: conf.Debriefs.Required,
+		},
+		Dashboard: service.DashboardOptions{
+			TTL:      conf.Dashboard.TTL,
+			TrendTTL: conf.Dashboard.TrendTTL,
+		},
+		Validator: structValidator,
 	})
 
+	return &application{
+		conf:      conf,
+		services:  s,
+		validator: structValidator,
+	}, nil
+}
+
+func serve(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	configPath := flags.String("config", "configs/stub.toml", "path to config file")
+	_ = flags.Parse(args)
+
+	a, err := bootstrap(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	conf, s := a.conf, a.services
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if conf.Overdue.Interval > 0 {
+		go s.Overdue.Run(ctx, conf.Overdue.Interval)
+	}
+	if conf.Dispatch.Interval > 0 {
+		go s.Dispatcher.Run(ctx, conf.Dispatch.Interval, conf.Dispatch.BatchSize)
+	}
+
+	bodyLimit := fiber.DefaultBodyLimit
+	if limit := int(conf.Attachments.MaxSize) + multipartOverhead; limit > bodyLimit {
+		bodyLimit = limit
+	}
+
 	app := fiber.New(fiber.Config{
-		ErrorHandler:    errors.ErrorHandler,
-		JSONEncoder:     json.Marshal,
-		JSONDecoder:     json.Unmarshal,
-		StructValidator: pkgvalidator.NewStructValidator(v),
+		BodyLimit:         bodyLimit,
+		StreamRequestBody: true,
+		ErrorHandler:      errors.ErrorHandler,
+		JSONEncoder:       json.Marshal,
+		JSONDecoder:       json.Unmarshal,
+		StructValidator:   a.validator,
 	})
 
 	app.Use(cors.New())
@@ -65,9 +169,56 @@ func main() {
 		TimeFormat: "2006-01-02 15:04:05",
 		TimeZone:   "Local",
 	}))
+	app.Use(limitBody(bodyLimit, "/import"))
 
 	h := handler.NewHandler(s)
 	h.RegisterRoutes(app)
 
 	log.Fatal(app.Listen(conf.ListenAddr))
 }
+
+func newBlobStore(conf *config.Config) (blob.Store, error) {
+	switch conf.Attachments.Driver {
+	case "", "local":
+		dir := conf.Attachments.Dir
+		if dir == "" {
+			dir = "data/attachments"
+		}
+		return blob.NewLocalStore(dir)
+	case "s3":
+		return blob.NewS3Store(blob.S3Options{
+			Endpoint:  conf.Attachments.S3.Endpoint,
+			Region:    conf.Attachments.S3.Region,
+			Bucket:    conf.Attachments.S3.Bucket,
+			AccessKey: conf.Attachments.S3.AccessKey,
+			SecretKey: conf.Attachments.S3.SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown attachments driver: %s", conf.Attachments.Driver)
+	}
+}
+
+func limitBody(limit int, streamed ...string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if slices.Contains(streamed, c.Path()) {
+			return c.Next()
+		}
+		if c.Request().Header.ContentLength() > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		stream := c.Request().BodyStream()
+		if stream == nil {
+			return c.Next()
+		}
+		body, err := io.ReadAll(io.LimitReader(stream, int64(limit)+1))
+		if err != nil {
+			return err
+		}
+		if len(body) > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+		c.Request().SetBody(body)
+		return c.Next()
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -25,6 +26,60 @@ type Config struct {
 	Breeds struct {
 		Url string
 	}
+
+	Overdue struct {
+		Interval time.Duration
+	}
+
+	Dispatch struct {
+		Interval  time.Duration
+		BatchSize int
+	}
+
+	Notify struct {
+		WebhookUrl string
+	}
+
+	Attachments struct {
+		Driver       string
+		Dir          string
+		MaxSize      int64
+		AllowedTypes []string
+		SigningKey   string
+		DownloadTTL  time.Duration
+
+		S3 struct {
+			Endpoint  string
+			Region    string
+			Bucket    string
+			AccessKey string
+			SecretKey string
+		}
+	}
+
+	Comments struct {
+		EditWindow time.Duration
+	}
+
+	Debriefs struct {
+		Required bool
+	}
+
+	Dashboard struct {
+		TTL      time.Duration
+		TrendTTL time.Duration
+	}
+
+	Recommend struct {
+		Experience    float64
+		Breed         float64
+		Workload      float64
+		Country       float64
+		Cost          float64
+		MaxExperience int
+		DefaultBreed  float64
+		Breeds        map[string]float64
+	}
 }
 
 func Load(configPath string) (*Config, error) {
@@ -32,5 +87,8 @@ func Load(configPath string) (*Config, error) {
 	if _, err := toml.DecodeFile(configPath, &conf); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %s, error: %v", configPath, err)
 	}
+	if conf.Attachments.SigningKey == "" {
+		return nil, fmt.Errorf("invalid config file: %s, error: attachments.SigningKey is required", configPath)
+	}
 	return &conf, nil
 }
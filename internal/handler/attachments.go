@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"mime"
+	"strconv"
+
+	"sca/internal/models"
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type AttachmentHandler struct {
+	service service.AttachmentService
+}
+
+func NewAttachmentHandler(service service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{service: service}
+}
+
+func (h *AttachmentHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/targets/:id/attachments", h.upload(models.OwnerTarget))
+	router.Get("/targets/:id/attachments", h.list(models.OwnerTarget))
+	router.Delete("/targets/:id/attachments/:attachmentId", h.delete(models.OwnerTarget))
+	router.Post("/missions/:id/attachments", h.upload(models.OwnerMission))
+	router.Get("/missions/:id/attachments", h.list(models.OwnerMission))
+	router.Delete("/missions/:id/attachments/:attachmentId", h.delete(models.OwnerMission))
+	router.Get("/attachments/:id/download", h.Download)
+}
+
+func (h *AttachmentHandler) upload(ownerType models.OwnerType) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			UploadedBy *string `form:"uploaded_by" validate:"omitempty,min=3,max=64"`
+		}
+		if err := c.Bind().Form(&req); err != nil {
+			return err
+		}
+
+		header, err := c.FormFile("file")
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Missing file field")
+		}
+		file, err := header.Open()
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		attachment, err := h.service.Upload(c.Context(), service.UploadAttachmentInput{
+			OwnerType:  ownerType,
+			OwnerId:    id,
+			Filename:   header.Filename,
+			Size:       header.Size,
+			Content:    file,
+			UploadedBy: req.UploadedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(&attachment)
+	}
+}
+
+func (h *AttachmentHandler) list(ownerType models.OwnerType) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+
+		attachments, err := h.service.List(c.Context(), ownerType, id)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusOK).JSON(&attachments)
+	}
+}
+
+func (h *AttachmentHandler) delete(ownerType models.OwnerType) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+		attachmentId, err := fiber.Convert(c.Params("attachmentId"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+
+		err = h.service.Delete(c.Context(), ownerType, id, attachmentId)
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Attachment deleted successfully"})
+	}
+}
+
+func (h *AttachmentHandler) Download(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Expires   int64  `query:"expires" validate:"required"`
+		Signature string `query:"signature" validate:"required,hexadecimal"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	attachment, content, err := h.service.Download(c.Context(), service.DownloadAttachmentInput{
+		ID:        id,
+		Expires:   req.Expires,
+		Signature: req.Signature,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	c.Set(fiber.HeaderETag, strconv.Quote(attachment.Checksum))
+	return c.Status(fiber.StatusOK).SendStream(content, int(attachment.Size))
+}
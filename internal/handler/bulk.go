@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func validateBulkItem(c fiber.Ctx, item any) error {
+	validator := c.App().Config().StructValidator
+	if validator == nil {
+		return nil
+	}
+	return validator.Validate(item)
+}
+
+func bulkMode(mode string) service.BulkMode {
+	if mode == "" {
+		return service.BulkAtomic
+	}
+	return service.BulkMode(mode)
+}
+
+func bulkStatus(result *service.BulkResult) int {
+	switch {
+	case result.Failed == 0:
+		return fiber.StatusCreated
+	case result.Created == 0:
+		return fiber.StatusUnprocessableEntity
+	default:
+		return fiber.StatusMultiStatus
+	}
+}
@@ -1,7 +1,14 @@
 package handler
 
 import (
+	"fmt"
+	"time"
+
+	"sca/internal/models"
 	"sca/internal/service"
+	"sca/pkg/errors"
+	"sca/pkg/money"
+	pkgvalidator "sca/pkg/validator"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
@@ -17,18 +24,29 @@ func NewCatHandler(service service.CatService) *CatHandler {
 
 func (h *CatHandler) RegisterRoutes(router fiber.Router) {
 	router.Post("/cats", h.Create)
+	router.Post("/cats/bulk", h.BulkCreate)
+	router.Get("/cats/available", h.Available)
+	router.Get("/cats/leaderboard", h.Leaderboard)
 	router.Get("/cats/:id", h.ById)
 	router.Get("/cats", h.List)
 	router.Patch("/cats/:id", h.Update)
 	router.Delete("/cats/:id", h.Delete)
+	router.Patch("/cats/:id/status", h.UpdateStatus)
+	router.Get("/cats/:id/leaves", h.Leaves)
+	router.Post("/cats/:id/leaves", h.AddLeave)
+	router.Delete("/cats/:id/leaves/:leaveId", h.DeleteLeave)
+	router.Get("/cats/:id/salary-history", h.SalaryHistory)
+	router.Put("/cats/:id/skills", h.UpdateSkills)
+	router.Get("/cats/:id/stats", h.Stats)
 }
 
 func (h *CatHandler) Create(c fiber.Ctx) error {
 	var req struct {
-		Name              string  `json:"name" validate:"required,min=3,max=32"`
-		YearsOfExperience int     `json:"years_of_experience" validate:"required,gte=0,lte=10"`
-		Breed             string  `json:"breed" validate:"required,breed"`
-		Salary            float64 `json:"salary" validate:"required,gt=0,lte=10000"`
+		Name              string      `json:"name" validate:"required,min=3,max=32"`
+		YearsOfExperience int         `json:"years_of_experience" validate:"required,gte=0,lte=10"`
+		Breed             string      `json:"breed" validate:"required,breed"`
+		Salary            money.Money `json:"salary" validate:"required,gt=0,lte=10000"`
+		Skills            []string    `json:"skills" validate:"omitempty,max=20,dive,skill"`
 	}
 	if err := c.Bind().JSON(&req); err != nil {
 		return err
@@ -39,6 +57,7 @@ func (h *CatHandler) Create(c fiber.Ctx) error {
 		YearsOfExperience: req.YearsOfExperience,
 		Breed:             req.Breed,
 		Salary:            req.Salary,
+		Skills:            req.Skills,
 	})
 	if err != nil {
 		return err
@@ -47,6 +66,56 @@ func (h *CatHandler) Create(c fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(&cat)
 }
 
+func (h *CatHandler) BulkCreate(c fiber.Ctx) error {
+	type item struct {
+		Name              string      `json:"name" validate:"required,min=3,max=32"`
+		YearsOfExperience int         `json:"years_of_experience" validate:"required,gte=0,lte=10"`
+		Breed             string      `json:"breed" validate:"required"`
+		Salary            money.Money `json:"salary" validate:"required,gt=0,lte=10000"`
+		Skills            []string    `json:"skills" validate:"omitempty,max=20,dive,skill"`
+	}
+	var req struct {
+		Mode  string `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+		Items []item `json:"items" validate:"required,min=1,max=100"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	knownBreed, err := pkgvalidator.BreedMatcher()
+	if err != nil {
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Cat breeds are unavailable")
+	}
+
+	items := make([]service.CreateCatInput, len(req.Items))
+	invalid := map[int]error{}
+	for i, it := range req.Items {
+		if err := validateBulkItem(c, &it); err != nil {
+			invalid[i] = err
+		} else if !knownBreed(it.Breed) {
+			invalid[i] = errors.ErrBadRequest{Msg: fmt.Sprintf("Unknown breed: %s", it.Breed)}
+		}
+		items[i] = service.CreateCatInput{
+			Name:              it.Name,
+			YearsOfExperience: it.YearsOfExperience,
+			Breed:             it.Breed,
+			Salary:            it.Salary,
+			Skills:            it.Skills,
+		}
+	}
+
+	result, err := h.service.BulkCreate(c.Context(), service.BulkCreateCatsInput{
+		Mode:    bulkMode(req.Mode),
+		Items:   items,
+		Invalid: invalid,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(bulkStatus(result)).JSON(&result)
+}
+
 func (h *CatHandler) ById(c fiber.Ctx) error {
 	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
 	if err != nil {
@@ -62,7 +131,16 @@ func (h *CatHandler) ById(c fiber.Ctx) error {
 }
 
 func (h *CatHandler) List(c fiber.Ctx) error {
-	cats, err := h.service.All(c.Context())
+	var req struct {
+		Skills []string `query:"skill" validate:"omitempty,max=20,dive,skill"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	cats, err := h.service.All(c.Context(), models.CatFilter{
+		Skills: req.Skills,
+	})
 	if err != nil {
 		return err
 	}
@@ -76,13 +154,20 @@ func (h *CatHandler) Update(c fiber.Ctx) error {
 	}
 
 	var req struct {
-		Salary float64 `json:"salary" validate:"required,gt=0,lte=10000"`
+		Salary        money.Money `json:"salary" validate:"required,gt=0,lte=10000"`
+		EffectiveFrom *time.Time  `json:"effective_from"`
+		ApprovedBy    string      `json:"approved_by" validate:"required,min=3,max=64"`
 	}
 	if err := c.Bind().JSON(&req); err != nil {
 		return err
 	}
 
-	cat, err := h.service.Update(c.Context(), id, req.Salary)
+	cat, err := h.service.Update(c.Context(), service.UpdateCatInput{
+		ID:            id,
+		Salary:        req.Salary,
+		EffectiveFrom: req.EffectiveFrom,
+		ApprovedBy:    req.ApprovedBy,
+	})
 	if err != nil {
 		return err
 	}
@@ -103,3 +188,165 @@ func (h *CatHandler) Delete(c fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Cat deleted successfully"})
 }
+
+func (h *CatHandler) Available(c fiber.Ctx) error {
+	var req struct {
+		From time.Time `query:"from" validate:"required"`
+		To   time.Time `query:"to" validate:"required,gtefield=From"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	cats, err := h.service.Available(c.Context(), req.From, req.To)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&cats)
+}
+
+func (h *CatHandler) UpdateStatus(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Status string `json:"status" validate:"required,oneof=active on_leave retired"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	cat, err := h.service.UpdateStatus(c.Context(), id, models.CatStatus(req.Status))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&cat)
+}
+
+func (h *CatHandler) Leaves(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	leaves, err := h.service.Leaves(c.Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&leaves)
+}
+
+func (h *CatHandler) AddLeave(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		StartsAt time.Time `json:"starts_at" validate:"required"`
+		EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+		Reason   *string   `json:"reason" validate:"omitempty,min=3,max=255"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	leave, err := h.service.AddLeave(c.Context(), service.AddLeaveInput{
+		CatId:    id,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(&leave)
+}
+
+func (h *CatHandler) DeleteLeave(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+	leaveId, err := fiber.Convert(c.Params("leaveId"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.DeleteLeave(c.Context(), id, leaveId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Leave deleted successfully"})
+}
+
+func (h *CatHandler) SalaryHistory(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	changes, err := h.service.SalaryHistory(c.Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&changes)
+}
+
+func (h *CatHandler) UpdateSkills(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Skills []string `json:"skills" validate:"max=20,dive,skill"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	cat, err := h.service.UpdateSkills(c.Context(), id, req.Skills)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&cat)
+}
+
+func (h *CatHandler) Stats(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	stats, err := h.service.Stats(c.Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&stats)
+}
+
+func (h *CatHandler) Leaderboard(c fiber.Ctx) error {
+	var req struct {
+		Sort  string `query:"sort" validate:"omitempty,oneof=missions_completed targets_completed countries on_time_rate avg_target_time"`
+		Limit int    `query:"limit" validate:"omitempty,gte=1,lte=100"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	stats, err := h.service.Leaderboard(c.Context(), models.CatLeaderboardFilter{
+		SortBy: req.Sort,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&stats)
+}
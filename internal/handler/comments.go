@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"sca/internal/models"
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type CommentHandler struct {
+	service service.CommentService
+}
+
+func NewCommentHandler(service service.CommentService) *CommentHandler {
+	return &CommentHandler{service: service}
+}
+
+func (h *CommentHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/targets/:id/comments", h.thread(models.OwnerTarget))
+	router.Post("/targets/:id/comments", h.add(models.OwnerTarget))
+	router.Patch("/targets/:id/comments/:commentId", h.edit(models.OwnerTarget))
+	router.Delete("/targets/:id/comments/:commentId", h.delete(models.OwnerTarget))
+	router.Get("/missions/:id/comments", h.thread(models.OwnerMission))
+	router.Post("/missions/:id/comments", h.add(models.OwnerMission))
+	router.Patch("/missions/:id/comments/:commentId", h.edit(models.OwnerMission))
+	router.Delete("/missions/:id/comments/:commentId", h.delete(models.OwnerMission))
+}
+
+func (h *CommentHandler) thread(ownerType models.OwnerType) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+
+		comments, err := h.service.Thread(c.Context(), ownerType, id)
+		if err != nil {
+			return err
+		}
+		return c.Status(fiber.StatusOK).JSON(&comments)
+	}
+}
+
+func (h *CommentHandler) add(ownerType models.OwnerType) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			ParentId *uuid.UUID `json:"parent_id"`
+			Author   string     `json:"author" validate:"required,min=3,max=64"`
+			Body     string     `json:"body" validate:"required,min=1,max=2000"`
+		}
+		if err := c.Bind().JSON(&req); err != nil {
+			return err
+		}
+
+		comment, err := h.service.Add(c.Context(), service.AddCommentInput{
+			OwnerType: ownerType,
+			OwnerId:   id,
+			ParentId:  req.ParentId,
+			Author:    req.Author,
+			Body:      req.Body,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(&comment)
+	}
+}
+
+func (h *CommentHandler) edit(ownerType models.OwnerType) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+		commentId, err := fiber.Convert(c.Params("commentId"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			Author string `json:"author" validate:"required,min=3,max=64"`
+			Body   string `json:"body" validate:"required,min=1,max=2000"`
+		}
+		if err := c.Bind().JSON(&req); err != nil {
+			return err
+		}
+
+		comment, err := h.service.Edit(c.Context(), service.EditCommentInput{
+			OwnerType: ownerType,
+			OwnerId:   id,
+			ID:        commentId,
+			Author:    req.Author,
+			Body:      req.Body,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusOK).JSON(&comment)
+	}
+}
+
+func (h *CommentHandler) delete(ownerType models.OwnerType) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+		commentId, err := fiber.Convert(c.Params("commentId"), uuid.Parse)
+		if err != nil {
+			return err
+		}
+
+		var req struct {
+			Author string `query:"author" validate:"required,min=3,max=64"`
+		}
+		if err := c.Bind().Query(&req); err != nil {
+			return err
+		}
+
+		err = h.service.Delete(c.Context(), service.DeleteCommentInput{
+			OwnerType: ownerType,
+			OwnerId:   id,
+			ID:        commentId,
+			Author:    req.Author,
+		})
+		if err != nil {
+			return err
+		}
+
+		return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Comment deleted successfully"})
+	}
+}
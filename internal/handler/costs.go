@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"time"
+
+	"sca/internal/service"
+	"sca/pkg/money"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type CostHandler struct {
+	service service.CostService
+}
+
+func NewCostHandler(service service.CostService) *CostHandler {
+	return &CostHandler{service: service}
+}
+
+func (h *CostHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/missions/budgets", h.Budgets)
+	router.Get("/missions/:id/cost", h.MissionCost)
+	router.Get("/missions/:id/expenses", h.Expenses)
+	router.Post("/missions/:id/expenses", h.AddExpense)
+	router.Delete("/missions/:id/expenses/:expenseId", h.DeleteExpense)
+}
+
+func (h *CostHandler) Budgets(c fiber.Ctx) error {
+	var req struct {
+		OverBudget bool `query:"over_budget"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	costs, err := h.service.Budgets(c.Context(), req.OverBudget)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&costs)
+}
+
+func (h *CostHandler) MissionCost(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	cost, err := h.service.MissionCost(c.Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&cost)
+}
+
+func (h *CostHandler) Expenses(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	expenses, err := h.service.Expenses(c.Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&expenses)
+}
+
+func (h *CostHandler) AddExpense(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Description string      `json:"description" validate:"required,min=3,max=255"`
+		Amount      money.Money `json:"amount" validate:"required,gt=0,lte=1000000"`
+		IncurredAt  *time.Time  `json:"incurred_at" validate:"omitempty,lte"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	incurredAt := time.Now().UTC()
+	if req.IncurredAt != nil {
+		incurredAt = *req.IncurredAt
+	}
+
+	expense, err := h.service.AddExpense(c.Context(), service.AddExpenseInput{
+		MissionId:   id,
+		Description: req.Description,
+		Amount:      req.Amount,
+		IncurredAt:  incurredAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(&expense)
+}
+
+func (h *CostHandler) DeleteExpense(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+	expenseId, err := fiber.Convert(c.Params("expenseId"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.DeleteExpense(c.Context(), id, expenseId)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Expense deleted successfully"})
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type DashboardHandler struct {
+	service service.DashboardService
+}
+
+func NewDashboardHandler(service service.DashboardService) *DashboardHandler {
+	return &DashboardHandler{service: service}
+}
+
+func (h *DashboardHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/dashboard", h.Summary)
+}
+
+func (h *DashboardHandler) Summary(c fiber.Ctx) error {
+	var req struct {
+		Days int `query:"days" validate:"omitempty,gte=1,lte=365"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	dashboard, err := h.service.Summary(c.Context(), service.DashboardInput{
+		Days: req.Days,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&dashboard)
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+const markdownContentType = "text/markdown; charset=utf-8"
+
+var debriefFuncs = map[string]any{
+	"date": func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04 UTC")
+	},
+	"inline": func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	},
+	"inc": func(i int) int {
+		return i + 1
+	},
+}
+
+var debriefMarkdown = template.Must(template.New("debrief.md").Funcs(debriefFuncs).Parse(`# Mission debrief {{.Mission.ID}}
+
+- **Status:** {{.Mission.Status}}
+- **Priority:** {{.Mission.Priority}}
+- **Cat:** {{if .Cat}}{{.Cat.Name}} ({{.Cat.Breed}}, {{.Cat.YearsOfExperience}} years){{else}}_unknown_{{end}}
+- **Cat rating:** {{.Debrief.CatRating}}/5
+- **Author:** {{.Debrief.Author}}
+- **Submitted:** {{date .Debrief.CreatedAt}}{{if .Debrief.UpdatedAt}}
+- **Updated:** {{date .Debrief.UpdatedAt}}{{end}}
+
+## Lessons learned
+
+{{.Debrief.LessonsLearned}}
+
+## Targets
+{{range $i, $t := .Targets}}
+### {{inc $i}}. {{$t.Target.Name}} ({{$t.Target.Country}})
+
+- **Outcome:** {{if $t.Outcome}}{{$t.Outcome.Outcome}}{{else}}_not recorded_{{end}}{{if and $t.Outcome $t.Outcome.Notes}}
+- **Outcome notes:** {{inline $t.Outcome.Notes}}{{end}}
+- **Completed:** {{if $t.Target.Complete}}yes{{else}}no{{end}}
+- **Current notes:** {{inline $t.Target.Notes}}
+
+#### Notes history
+{{if $t.Notes}}{{range $t.Notes}}
+- v{{.Version}} on {{date .CreatedAt}}{{if .Author}} by {{.Author}}{{end}}: {{inline .Notes}}{{end}}{{else}}
+_No revisions recorded._{{end}}
+{{end}}
+## Timeline
+{{range .Transitions}}
+- {{date .CreatedAt}}: {{if .FromStatus}}{{.FromStatus}} → {{end}}{{.ToStatus}}{{if .Reason}} ({{inline .Reason}}){{end}}{{end}}
+
+_Generated at {{date .GeneratedAt}}_
+`))
+
+var debriefHTML = htmltemplate.Must(htmltemplate.New("debrief.html").Funcs(debriefFuncs).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Mission debrief {{.Mission.ID}}</title>
+</head>
+<body>
+<h1>Mission debrief {{.Mission.ID}}</h1>
+<ul>
+<li><strong>Status:</strong> {{.Mission.Status}}</li>
+<li><strong>Priority:</strong> {{.Mission.Priority}}</li>
+<li><strong>Cat:</strong> {{if .Cat}}{{.Cat.Name}} ({{.Cat.Breed}}, {{.Cat.YearsOfExperience}} years){{else}}<em>unknown</em>{{end}}</li>
+<li><strong>Cat rating:</strong> {{.Debrief.CatRating}}/5</li>
+<li><strong>Author:</strong> {{.Debrief.Author}}</li>
+<li><strong>Submitted:</strong> {{date .Debrief.CreatedAt}}</li>{{if .Debrief.UpdatedAt}}
+<li><strong>Updated:</strong> {{date .Debrief.UpdatedAt}}</li>{{end}}
+</ul>
+<h2>Lessons learned</h2>
+<p style="white-space: pre-wrap">{{.Debrief.LessonsLearned}}</p>
+<h2>Targets</h2>
+{{range $i, $t := .Targets}}<h3>{{inc $i}}. {{$t.Target.Name}} ({{$t.Target.Country}})</h3>
+<ul>
+<li><strong>Outcome:</strong> {{if $t.Outcome}}{{$t.Outcome.Outcome}}{{else}}<em>not recorded</em>{{end}}</li>{{if and $t.Outcome $t.Outcome.Notes}}
+<li><strong>Outcome notes:</strong> {{$t.Outcome.Notes}}</li>{{end}}
+<li><strong>Completed:</strong> {{if $t.Target.Complete}}yes{{else}}no{{end}}</li>
+<li><strong>Current notes:</strong> {{$t.Target.Notes}}</li>
+</ul>
+<h4>Notes history</h4>
+{{if $t.Notes}}<ol>{{range $t.Notes}}
+<li>v{{.Version}} on {{date .CreatedAt}}{{if .Author}} by {{.Author}}{{end}}: {{.Notes}}</li>{{end}}
+</ol>{{else}}<p><em>No revisions recorded.</em></p>{{end}}
+{{end}}<h2>Timeline</h2>
+<ul>{{range .Transitions}}
+<li>{{date .CreatedAt}}: {{if .FromStatus}}{{.FromStatus}} → {{end}}{{.ToStatus}}{{if .Reason}} ({{.Reason}}){{end}}</li>{{end}}
+</ul>
+<p><em>Generated at {{date .GeneratedAt}}</em></p>
+</body>
+</html>
+`))
+
+type DebriefHandler struct {
+	service service.DebriefService
+}
+
+func NewDebriefHandler(service service.DebriefService) *DebriefHandler {
+	return &DebriefHandler{service: service}
+}
+
+func (h *DebriefHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/missions/:id/debrief", h.Submit)
+	router.Get("/missions/:id/debrief", h.ByMission)
+	router.Get("/missions/:id/debrief/report", h.Report)
+}
+
+func (h *DebriefHandler) Submit(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		CatRating      int    `json:"cat_rating" validate:"required,gte=1,lte=5"`
+		LessonsLearned string `json:"lessons_learned" validate:"required,min=3,max=5000"`
+		Author         string `json:"author" validate:"required,min=3,max=64"`
+		Outcomes       []struct {
+			TargetId uuid.UUID `json:"target_id" validate:"required"`
+			Outcome  string    `json:"outcome" validate:"required,oneof=achieved partial missed"`
+			Notes    string    `json:"notes" validate:"max=255"`
+		} `json:"outcomes" validate:"required,min=1,max=3,dive"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	outcomes := make([]service.DebriefOutcomeInput, len(req.Outcomes))
+	for i, o := range req.Outcomes {
+		outcomes[i] = service.DebriefOutcomeInput{
+			TargetId: o.TargetId,
+			Outcome:  models.TargetOutcome(o.Outcome),
+			Notes:    o.Notes,
+		}
+	}
+
+	debrief, err := h.service.Submit(c.Context(), service.SubmitDebriefInput{
+		MissionId:      id,
+		CatRating:      req.CatRating,
+		LessonsLearned: req.LessonsLearned,
+		Author:         req.Author,
+		Outcomes:       outcomes,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(&debrief)
+}
+
+func (h *DebriefHandler) ByMission(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	debrief, err := h.service.ByMission(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&debrief)
+}
+
+func (h *DebriefHandler) Report(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Format string `query:"format" validate:"omitempty,oneof=markdown html json"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	report, err := h.service.Report(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	switch req.Format {
+	case "json":
+		return c.Status(fiber.StatusOK).JSON(&report)
+	case "html":
+		body, err := renderDebrief(debriefHTML, report)
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Status(fiber.StatusOK).Send(body)
+	default:
+		body, err := renderDebrief(debriefMarkdown, report)
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, markdownContentType)
+		return c.Status(fiber.StatusOK).Send(body)
+	}
+}
+
+func renderDebrief(tmpl interface {
+	Execute(w io.Writer, data any) error
+}, report *service.DebriefReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, report); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
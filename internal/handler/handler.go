@@ -7,21 +7,48 @@ import (
 )
 
 type Handler struct {
-	cats     *CatHandler
-	missions *MissionHandler
-	targets  *TargetHandler
+	cats        *CatHandler
+	missions    *MissionHandler
+	targets     *TargetHandler
+	payroll     *PayrollHandler
+	costs       *CostHandler
+	recommend   *RecommendHandler
+	templates   *TemplateHandler
+	attachments *AttachmentHandler
+	comments    *CommentHandler
+	debriefs    *DebriefHandler
+	transfer    *TransferHandler
+	dashboard   *DashboardHandler
 }
 
 func NewHandler(service *service.Service) *Handler {
 	return &Handler{
-		cats:     NewCatHandler(service.Cats),
-		missions: NewMissionHandler(service.Missions),
-		targets:  NewTargetHandler(service.Targets),
+		cats:        NewCatHandler(service.Cats),
+		missions:    NewMissionHandler(service.Missions),
+		targets:     NewTargetHandler(service.Targets),
+		payroll:     NewPayrollHandler(service.Payroll),
+		costs:       NewCostHandler(service.Costs),
+		recommend:   NewRecommendHandler(service.Recommend),
+		templates:   NewTemplateHandler(service.Templates),
+		attachments: NewAttachmentHandler(service.Attachments),
+		comments:    NewCommentHandler(service.Comments),
+		debriefs:    NewDebriefHandler(service.Debriefs),
+		transfer:    NewTransferHandler(service.Transfer),
+		dashboard:   NewDashboardHandler(service.Dashboard),
 	}
 }
 
 func (s *Handler) RegisterRoutes(router fiber.Router) {
 	s.cats.RegisterRoutes(router)
+	s.costs.RegisterRoutes(router)
+	s.recommend.RegisterRoutes(router)
+	s.templates.RegisterRoutes(router)
+	s.attachments.RegisterRoutes(router)
+	s.comments.RegisterRoutes(router)
+	s.debriefs.RegisterRoutes(router)
+	s.transfer.RegisterRoutes(router)
+	s.dashboard.RegisterRoutes(router)
 	s.missions.RegisterRoutes(router)
 	s.targets.RegisterRoutes(router)
+	s.payroll.RegisterRoutes(router)
 }
@@ -1,7 +1,12 @@
 package handler
 
 import (
+	"time"
+
+	"sca/internal/models"
 	"sca/internal/service"
+	"sca/pkg/geojson"
+	"sca/pkg/money"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/google/uuid"
@@ -17,22 +22,44 @@ func NewMissionHandler(service service.MissionService) *MissionHandler {
 
 func (h *MissionHandler) RegisterRoutes(router fiber.Router) {
 	router.Post("/missions", h.Create)
+	router.Post("/missions/from-template/:id", h.CreateFromTemplate)
 	router.Get("/missions/:id", h.ById)
 	router.Get("/missions", h.List)
+	router.Patch("/missions/:id", h.Update)
 	router.Delete("/missions/:id", h.Delete)
 	router.Post("/missions/assign-cat", h.AssignCat)
+	router.Post("/missions/:id/unassign-cat", h.UnassignCat)
+	router.Post("/missions/:id/reassign-cat", h.ReassignCat)
+	router.Get("/missions/:id/assignments", h.Assignments)
 	router.Post("/missions/:id/complete", h.MarkComplete)
+	router.Post("/missions/:id/start", h.Start)
+	router.Post("/missions/:id/abort", h.Abort)
+	router.Post("/missions/:id/fail", h.Fail)
+	router.Get("/missions/:id/transitions", h.Transitions)
+	router.Get("/missions/:id/geojson", h.GeoJSON)
+	router.Post("/missions/:id/clone", h.Clone)
 	router.Post("/missions/:id/targets", h.AddTarget)
+	router.Put("/missions/:id/targets/order", h.ReorderTargets)
+	router.Delete("/missions/:id/targets/:targetId", h.DetachTarget)
+	router.Post("/missions/:id/targets/:targetId/move", h.MoveTarget)
 }
 
 func (h *MissionHandler) Create(c fiber.Ctx) error {
 	var req struct {
-		CatId   uuid.UUID `json:"cat_id" validate:"omitempty,uuid"`
-		Targets []struct {
-			Name    string `json:"name" validate:"required,min=3,max=32"`
-			Country string `json:"country" validate:"required,min=3,max=32"`
-			Notes   string `json:"notes" validate:"required,min=3,max=255"`
-		} `json:"targets" validate:"omitempty,min=1,max=3,dive"`
+		CatId    uuid.UUID   `json:"cat_id" validate:"omitempty,uuid"`
+		Priority int         `json:"priority" validate:"omitempty,gte=1,lte=5"`
+		DueAt    *time.Time  `json:"due_at" validate:"omitempty,gt"`
+		Budget   money.Money `json:"budget" validate:"gte=0"`
+		Targets  []struct {
+			Name           string     `json:"name" validate:"required,min=3,max=32"`
+			Country        string     `json:"country" validate:"required,country"`
+			Latitude       *float64   `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+			Longitude      *float64   `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+			LastSeenAt     *time.Time `json:"last_seen_at" validate:"omitempty,lte"`
+			Notes          string     `json:"notes" validate:"required,min=3,max=255"`
+			RequiredSkills []string   `json:"required_skills" validate:"omitempty,max=20,dive,skill"`
+		} `json:"targets" validate:"required,min=1,max=3,dive"`
+		IgnoreSkills bool `json:"ignore_skills"`
 	}
 	if err := c.Bind().JSON(&req); err != nil {
 		return err
@@ -41,15 +68,83 @@ func (h *MissionHandler) Create(c fiber.Ctx) error {
 	inputTargets := make([]service.CreateTargetInput, len(req.Targets))
 	for i, t := range req.Targets {
 		inputTargets[i] = service.CreateTargetInput{
-			Name:    t.Name,
-			Country: t.Country,
-			Notes:   t.Notes,
+			Name:           t.Name,
+			Country:        t.Country,
+			Latitude:       t.Latitude,
+			Longitude:      t.Longitude,
+			LastSeenAt:     t.LastSeenAt,
+			Notes:          t.Notes,
+			RequiredSkills: t.RequiredSkills,
 		}
 	}
 
 	mission, err := h.service.Create(c.Context(), service.CreateMissionInput{
-		CatId:   req.CatId,
-		Targets: inputTargets,
+		CatId:        req.CatId,
+		Priority:     req.Priority,
+		DueAt:        req.DueAt,
+		Budget:       req.Budget,
+		Targets:      inputTargets,
+		IgnoreSkills: req.IgnoreSkills,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(&mission)
+}
+
+func (h *MissionHandler) CreateFromTemplate(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		CatId        uuid.UUID  `json:"cat_id" validate:"omitempty,uuid"`
+		DueAt        *time.Time `json:"due_at" validate:"omitempty,gt"`
+		IgnoreSkills bool       `json:"ignore_skills"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(&req); err != nil {
+			return err
+		}
+	}
+
+	mission, err := h.service.CreateFromTemplate(c.Context(), service.CreateFromTemplateInput{
+		TemplateId:   id,
+		CatId:        req.CatId,
+		DueAt:        req.DueAt,
+		IgnoreSkills: req.IgnoreSkills,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(&mission)
+}
+
+func (h *MissionHandler) Clone(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		CatId        uuid.UUID  `json:"cat_id" validate:"omitempty,uuid"`
+		DueAt        *time.Time `json:"due_at" validate:"omitempty,gt"`
+		IgnoreSkills bool       `json:"ignore_skills"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(&req); err != nil {
+			return err
+		}
+	}
+
+	mission, err := h.service.Clone(c.Context(), service.CloneMissionInput{
+		ID:           id,
+		CatId:        req.CatId,
+		DueAt:        req.DueAt,
+		IgnoreSkills: req.IgnoreSkills,
 	})
 	if err != nil {
 		return err
@@ -73,13 +168,62 @@ func (h *MissionHandler) ById(c fiber.Ctx) error {
 }
 
 func (h *MissionHandler) List(c fiber.Ctx) error {
-	missions, err := h.service.All(c.Context())
+	var req struct {
+		Status    string    `query:"status" validate:"omitempty,oneof=draft assigned in_progress completed aborted failed"`
+		Priority  int       `query:"priority" validate:"omitempty,gte=1,lte=5"`
+		Overdue   *bool     `query:"overdue"`
+		DueBefore time.Time `query:"due_before"`
+		DueAfter  time.Time `query:"due_after"`
+		Sort      string    `query:"sort" validate:"omitempty,oneof=priority due_at"`
+		Order     string    `query:"order" validate:"omitempty,oneof=asc desc"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	missions, err := h.service.All(c.Context(), models.MissionFilter{
+		Status:    models.MissionStatus(req.Status),
+		Priority:  req.Priority,
+		Overdue:   req.Overdue,
+		DueBefore: req.DueBefore,
+		DueAfter:  req.DueAfter,
+		SortBy:    req.Sort,
+		Order:     req.Order,
+	})
 	if err != nil {
 		return err
 	}
 	return c.Status(fiber.StatusOK).JSON(&missions)
 }
 
+func (h *MissionHandler) Update(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Priority *int         `json:"priority" validate:"omitempty,gte=1,lte=5"`
+		DueAt    *time.Time   `json:"due_at" validate:"omitempty,gt"`
+		Budget   *money.Money `json:"budget" validate:"omitempty,gte=0"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	mission, err := h.service.Update(c.Context(), service.UpdateMissionInput{
+		ID:       id,
+		Priority: req.Priority,
+		DueAt:    req.DueAt,
+		Budget:   req.Budget,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&mission)
+}
+
 func (h *MissionHandler) Delete(c fiber.Ctx) error {
 	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
 	if err != nil {
@@ -100,7 +244,19 @@ func (h *MissionHandler) MarkComplete(c fiber.Ctx) error {
 		return err
 	}
 
-	err = h.service.MarkComplete(c.Context(), id)
+	var req struct {
+		Force  bool   `query:"force"`
+		Reason string `query:"reason" validate:"required_if=Force true,max=255"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	err = h.service.MarkComplete(c.Context(), service.MarkCompleteMissionInput{
+		ID:     id,
+		Force:  req.Force,
+		Reason: req.Reason,
+	})
 	if err != nil {
 		return err
 	}
@@ -108,18 +264,98 @@ func (h *MissionHandler) MarkComplete(c fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Mission marked as complete"})
 }
 
+func (h *MissionHandler) Start(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Start(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Mission started"})
+}
+
+func (h *MissionHandler) Abort(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Reason string `json:"reason" validate:"required,min=3,max=255"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	err = h.service.Abort(c.Context(), service.TransitionMissionInput{
+		ID:     id,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Mission aborted"})
+}
+
+func (h *MissionHandler) Fail(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Reason string `json:"reason" validate:"required,min=3,max=255"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	err = h.service.Fail(c.Context(), service.TransitionMissionInput{
+		ID:     id,
+		Reason: req.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Mission marked as failed"})
+}
+
+func (h *MissionHandler) Transitions(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	transitions, err := h.service.Transitions(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&transitions)
+}
+
 func (h *MissionHandler) AssignCat(c fiber.Ctx) error {
 	var req struct {
-		MissionId uuid.UUID `json:"mission_id" validate:"required,uuid"`
-		CatId     uuid.UUID `json:"cat_id" validate:"required,uuid"`
+		MissionId    uuid.UUID `json:"mission_id" validate:"required,uuid"`
+		CatId        uuid.UUID `json:"cat_id" validate:"required,uuid"`
+		Reason       *string   `json:"reason" validate:"omitempty,min=3,max=255"`
+		IgnoreSkills bool      `json:"ignore_skills"`
 	}
 	if err := c.Bind().JSON(&req); err != nil {
 		return err
 	}
 
 	err := h.service.AssignCat(c.Context(), service.AssignCatInput{
-		MissionId: req.MissionId,
-		CatId:     req.CatId,
+		MissionId:    req.MissionId,
+		CatId:        req.CatId,
+		Reason:       req.Reason,
+		IgnoreSkills: req.IgnoreSkills,
 	})
 	if err != nil {
 		return err
@@ -128,6 +364,72 @@ func (h *MissionHandler) AssignCat(c fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Cat assigned to mission"})
 }
 
+func (h *MissionHandler) UnassignCat(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Reason string `json:"reason" validate:"required,min=3,max=255"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	err = h.service.UnassignCat(c.Context(), service.UnassignCatInput{
+		MissionId: id,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Cat unassigned from mission"})
+}
+
+func (h *MissionHandler) ReassignCat(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		CatId        uuid.UUID `json:"cat_id" validate:"required,uuid"`
+		Reason       string    `json:"reason" validate:"required,min=3,max=255"`
+		IgnoreSkills bool      `json:"ignore_skills"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	err = h.service.ReassignCat(c.Context(), service.ReassignCatInput{
+		MissionId:    id,
+		CatId:        req.CatId,
+		Reason:       req.Reason,
+		IgnoreSkills: req.IgnoreSkills,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Mission reassigned to another cat"})
+}
+
+func (h *MissionHandler) Assignments(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	assignments, err := h.service.Assignments(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&assignments)
+}
+
 func (h *MissionHandler) AddTarget(c fiber.Ctx) error {
 	var req struct {
 		MissionId uuid.UUID `json:"mission_id" validate:"required,uuid"`
@@ -147,3 +449,91 @@ func (h *MissionHandler) AddTarget(c fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Target added to mission"})
 }
+
+func (h *MissionHandler) DetachTarget(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+	targetId, err := fiber.Convert(c.Params("targetId"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.DetachTarget(c.Context(), service.DetachTargetInput{
+		MissionId: id,
+		TargetId:  targetId,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Target detached from mission"})
+}
+
+func (h *MissionHandler) MoveTarget(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+	targetId, err := fiber.Convert(c.Params("targetId"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		MissionId uuid.UUID `json:"mission_id" validate:"required,uuid"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	err = h.service.MoveTarget(c.Context(), service.MoveTargetInput{
+		MissionId:   id,
+		TargetId:    targetId,
+		ToMissionId: req.MissionId,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Target moved to another mission"})
+}
+
+func (h *MissionHandler) ReorderTargets(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		TargetIds []uuid.UUID `json:"target_ids" validate:"required,min=1,max=3"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	err = h.service.ReorderTargets(c.Context(), service.ReorderTargetsInput{
+		MissionId: id,
+		TargetIds: req.TargetIds,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Mission targets reordered"})
+}
+
+func (h *MissionHandler) GeoJSON(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	collection, err := h.service.GeoJSON(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&collection, geojson.ContentType)
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"time"
+
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+const maxPayrollMonths = 36
+
+type PayrollHandler struct {
+	service service.PayrollService
+}
+
+func NewPayrollHandler(service service.PayrollService) *PayrollHandler {
+	return &PayrollHandler{service: service}
+}
+
+func (h *PayrollHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/payroll", h.Report)
+}
+
+func (h *PayrollHandler) Report(c fiber.Ctx) error {
+	var req struct {
+		From   string `query:"from" validate:"required,datetime=2006-01"`
+		To     string `query:"to" validate:"required,datetime=2006-01"`
+		Format string `query:"format" validate:"omitempty,oneof=json csv"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	from, _ := time.Parse("2006-01", req.From)
+	to, _ := time.Parse("2006-01", req.To)
+	if to.Before(from) {
+		return fiber.NewError(fiber.StatusBadRequest, "to must not be before from")
+	}
+	if to.After(from.AddDate(0, maxPayrollMonths-1, 0)) {
+		return fiber.NewError(fiber.StatusBadRequest, "payroll range must not exceed 36 months")
+	}
+
+	report, err := h.service.Report(c.Context(), service.PayrollInput{
+		From: from,
+		To:   to,
+	})
+	if err != nil {
+		return err
+	}
+
+	if req.Format == "csv" {
+		body, err := payrollCSV(report)
+		if err != nil {
+			return err
+		}
+		c.Attachment("payroll.csv")
+		c.Set(fiber.HeaderContentType, "text/csv")
+		return c.Status(fiber.StatusOK).Send(body)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&report)
+}
+
+func payrollCSV(report *service.PayrollReport) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	_ = w.Write([]string{"month", "cat_id", "cat_name", "amount", "currency"})
+	for _, month := range report.Months {
+		for _, line := range month.Cats {
+			_ = w.Write([]string{month.Month, line.CatId.String(), line.CatName, line.Amount.Amount.String(), line.Amount.Currency})
+		}
+		for _, total := range month.Totals {
+			_ = w.Write([]string{month.Month, "", "Agency total", total.Amount.String(), total.Currency})
+		}
+	}
+	for _, total := range report.Totals {
+		_ = w.Write([]string{"", "", "Grand total", total.Amount.String(), total.Currency})
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type RecommendHandler struct {
+	service service.RecommendService
+}
+
+func NewRecommendHandler(service service.RecommendService) *RecommendHandler {
+	return &RecommendHandler{service: service}
+}
+
+func (h *RecommendHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/missions/:id/recommended-cats", h.RecommendCats)
+}
+
+func (h *RecommendHandler) RecommendCats(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Limit int `query:"limit" validate:"omitempty,min=1,max=100"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	recommendations, err := h.service.RecommendCats(c.Context(), service.RecommendCatsInput{
+		MissionId: id,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&recommendations)
+}
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"time"
+
 	"sca/internal/service"
 
 	"github.com/gofiber/fiber/v3"
@@ -17,27 +19,42 @@ func NewTargetHandler(service service.TargetService) *TargetHandler {
 
 func (h *TargetHandler) RegisterRoutes(router fiber.Router) {
 	router.Post("/targets", h.Create)
+	router.Post("/targets/bulk", h.BulkCreate)
+	router.Get("/targets/nearby", h.Nearby)
 	router.Get("/targets/:id", h.ById)
 	router.Get("/targets", h.List)
 	router.Patch("/targets/:id/notes", h.UpdateNotes)
+	router.Get("/targets/:id/notes/history", h.NotesHistory)
+	router.Get("/targets/:id/notes/history/diff", h.NotesDiff)
+	router.Post("/targets/:id/notes/restore", h.RestoreNotes)
+	router.Patch("/targets/:id/location", h.UpdateLocation)
+	router.Put("/targets/:id/required-skills", h.UpdateRequiredSkills)
 	router.Delete("/targets/:id", h.Delete)
 	router.Post("/targets/:id/complete", h.MarkComplete)
 }
 
 func (h *TargetHandler) Create(c fiber.Ctx) error {
 	var req struct {
-		Name    string `json:"name" validate:"required,min=3,max=32"`
-		Country string `json:"country" validate:"required,min=3,max=32"`
-		Notes   string `json:"notes" validate:"required,min=3,max=255"`
+		Name           string     `json:"name" validate:"required,min=3,max=32"`
+		Country        string     `json:"country" validate:"required,country"`
+		Latitude       *float64   `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+		Longitude      *float64   `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+		LastSeenAt     *time.Time `json:"last_seen_at" validate:"omitempty,lte"`
+		Notes          string     `json:"notes" validate:"required,min=3,max=255"`
+		RequiredSkills []string   `json:"required_skills" validate:"omitempty,max=20,dive,skill"`
 	}
 	if err := c.Bind().JSON(&req); err != nil {
 		return err
 	}
 
 	target, err := h.service.Create(c.Context(), service.CreateTargetInput{
-		Name:    req.Name,
-		Country: req.Country,
-		Notes:   req.Notes,
+		Name:           req.Name,
+		Country:        req.Country,
+		Latitude:       req.Latitude,
+		Longitude:      req.Longitude,
+		LastSeenAt:     req.LastSeenAt,
+		Notes:          req.Notes,
+		RequiredSkills: req.RequiredSkills,
 	})
 	if err != nil {
 		return err
@@ -46,6 +63,53 @@ func (h *TargetHandler) Create(c fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(&target)
 }
 
+func (h *TargetHandler) BulkCreate(c fiber.Ctx) error {
+	type item struct {
+		Name           string     `json:"name" validate:"required,min=3,max=32"`
+		Country        string     `json:"country" validate:"required,country"`
+		Latitude       *float64   `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+		Longitude      *float64   `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+		LastSeenAt     *time.Time `json:"last_seen_at" validate:"omitempty,lte"`
+		Notes          string     `json:"notes" validate:"required,min=3,max=255"`
+		RequiredSkills []string   `json:"required_skills" validate:"omitempty,max=20,dive,skill"`
+	}
+	var req struct {
+		Mode  string `json:"mode" validate:"omitempty,oneof=atomic best_effort"`
+		Items []item `json:"items" validate:"required,min=1,max=100"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	items := make([]service.CreateTargetInput, len(req.Items))
+	invalid := map[int]error{}
+	for i, it := range req.Items {
+		if err := validateBulkItem(c, &it); err != nil {
+			invalid[i] = err
+		}
+		items[i] = service.CreateTargetInput{
+			Name:           it.Name,
+			Country:        it.Country,
+			Latitude:       it.Latitude,
+			Longitude:      it.Longitude,
+			LastSeenAt:     it.LastSeenAt,
+			Notes:          it.Notes,
+			RequiredSkills: it.RequiredSkills,
+		}
+	}
+
+	result, err := h.service.BulkCreate(c.Context(), service.BulkCreateTargetsInput{
+		Mode:    bulkMode(req.Mode),
+		Items:   items,
+		Invalid: invalid,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(bulkStatus(result)).JSON(&result)
+}
+
 func (h *TargetHandler) ById(c fiber.Ctx) error {
 	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
 	if err != nil {
@@ -103,15 +167,17 @@ func (h *TargetHandler) UpdateNotes(c fiber.Ctx) error {
 	}
 
 	var req struct {
-		Notes string `json:"notes" validate:"required,min=3,max=255"`
+		Notes  string `json:"notes" validate:"required,min=3,max=255"`
+		Author string `json:"author" validate:"omitempty,min=3,max=64"`
 	}
 	if err := c.Bind().JSON(&req); err != nil {
 		return err
 	}
 
 	err = h.service.UpdateNotes(c.Context(), service.UpdateNotesInput{
-		ID:    id,
-		Notes: req.Notes,
+		ID:     id,
+		Notes:  req.Notes,
+		Author: req.Author,
 	})
 	if err != nil {
 		return err
@@ -119,3 +185,139 @@ func (h *TargetHandler) UpdateNotes(c fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Target notes updated successfully"})
 }
+
+func (h *TargetHandler) NotesHistory(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := h.service.NotesHistory(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&revisions)
+}
+
+func (h *TargetHandler) NotesDiff(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		From int `query:"from" validate:"required,gte=1"`
+		To   int `query:"to" validate:"required,gte=1"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	notesDiff, err := h.service.NotesDiff(c.Context(), service.NotesDiffInput{
+		ID:   id,
+		From: req.From,
+		To:   req.To,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&notesDiff)
+}
+
+func (h *TargetHandler) RestoreNotes(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Version int    `json:"version" validate:"required,gte=1"`
+		Author  string `json:"author" validate:"required,min=3,max=64"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	err = h.service.RestoreNotes(c.Context(), service.RestoreNotesInput{
+		ID:      id,
+		Version: req.Version,
+		Author:  req.Author,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Target notes restored successfully"})
+}
+
+func (h *TargetHandler) UpdateLocation(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Latitude   *float64   `json:"latitude" validate:"required,latitude"`
+		Longitude  *float64   `json:"longitude" validate:"required,longitude"`
+		LastSeenAt *time.Time `json:"last_seen_at" validate:"omitempty,lte"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	target, err := h.service.UpdateLocation(c.Context(), service.UpdateLocationInput{
+		ID:         id,
+		Latitude:   *req.Latitude,
+		Longitude:  *req.Longitude,
+		LastSeenAt: req.LastSeenAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&target)
+}
+
+func (h *TargetHandler) Nearby(c fiber.Ctx) error {
+	var req struct {
+		Lat      *float64 `query:"lat" validate:"required,latitude"`
+		Lng      *float64 `query:"lng" validate:"required,longitude"`
+		RadiusKm float64  `query:"radius_km" validate:"required,gt=0,lte=20000"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	targets, err := h.service.Nearby(c.Context(), service.NearbyInput{
+		Latitude:  *req.Lat,
+		Longitude: *req.Lng,
+		RadiusKm:  req.RadiusKm,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&targets)
+}
+
+func (h *TargetHandler) UpdateRequiredSkills(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Skills []string `json:"skills" validate:"max=20,dive,skill"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	target, err := h.service.UpdateRequiredSkills(c.Context(), id, req.Skills)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&target)
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"sca/internal/service"
+	"sca/pkg/money"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type TemplateHandler struct {
+	service service.TemplateService
+}
+
+func NewTemplateHandler(service service.TemplateService) *TemplateHandler {
+	return &TemplateHandler{service: service}
+}
+
+func (h *TemplateHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/mission-templates", h.Create)
+	router.Get("/mission-templates/:id", h.ById)
+	router.Get("/mission-templates", h.List)
+	router.Delete("/mission-templates/:id", h.Delete)
+}
+
+func (h *TemplateHandler) Create(c fiber.Ctx) error {
+	var req struct {
+		Name     string      `json:"name" validate:"required,min=3,max=64"`
+		Priority int         `json:"priority" validate:"omitempty,gte=1,lte=5"`
+		Budget   money.Money `json:"budget" validate:"gte=0"`
+		Targets  []struct {
+			Name           string   `json:"name" validate:"required,min=3,max=32"`
+			Country        string   `json:"country" validate:"required,country"`
+			Notes          string   `json:"notes" validate:"required,min=3,max=255"`
+			RequiredSkills []string `json:"required_skills" validate:"omitempty,max=20,dive,skill"`
+		} `json:"targets" validate:"required,min=1,max=3,dive"`
+	}
+	if err := c.Bind().JSON(&req); err != nil {
+		return err
+	}
+
+	inputTargets := make([]service.CreateTemplateTargetInput, len(req.Targets))
+	for i, t := range req.Targets {
+		inputTargets[i] = service.CreateTemplateTargetInput{
+			Name:           t.Name,
+			Country:        t.Country,
+			Notes:          t.Notes,
+			RequiredSkills: t.RequiredSkills,
+		}
+	}
+
+	template, err := h.service.Create(c.Context(), service.CreateTemplateInput{
+		Name:     req.Name,
+		Priority: req.Priority,
+		Budget:   req.Budget,
+		Targets:  inputTargets,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(&template)
+}
+
+func (h *TemplateHandler) ById(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	template, err := h.service.ById(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&template)
+}
+
+func (h *TemplateHandler) List(c fiber.Ctx) error {
+	templates, err := h.service.All(c.Context())
+	if err != nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(&templates)
+}
+
+func (h *TemplateHandler) Delete(c fiber.Ctx) error {
+	id, err := fiber.Convert(c.Params("id"), uuid.Parse)
+	if err != nil {
+		return err
+	}
+
+	err = h.service.Delete(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&fiber.Map{"message": "Mission template deleted successfully"})
+}
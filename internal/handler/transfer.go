@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"log"
+
+	"sca/internal/service"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+var transferContentTypes = map[service.TransferFormat]string{
+	service.FormatCSV:    "text/csv",
+	service.FormatJSON:   fiber.MIMEApplicationJSON,
+	service.FormatNDJSON: "application/x-ndjson",
+}
+
+type TransferHandler struct {
+	service service.TransferService
+}
+
+func NewTransferHandler(service service.TransferService) *TransferHandler {
+	return &TransferHandler{service: service}
+}
+
+func (h *TransferHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/export", h.Export)
+	router.Post("/import", h.Import)
+}
+
+func (h *TransferHandler) Export(c fiber.Ctx) error {
+	var req struct {
+		Entity string `query:"entity" validate:"required,oneof=cats missions targets"`
+		Format string `query:"format" validate:"omitempty,oneof=csv json ndjson"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	format := service.TransferFormat(req.Format)
+	if format == "" {
+		format = service.FormatJSON
+	}
+
+	write, err := h.service.Export(c.Context(), service.ExportInput{
+		Entity: service.TransferEntity(req.Entity),
+		Format: format,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Attachment(req.Entity + "." + string(format))
+	c.Set(fiber.HeaderContentType, transferContentTypes[format])
+	return c.Status(fiber.StatusOK).SendStreamWriter(func(w *bufio.Writer) {
+		if err := write(w); err != nil {
+			log.Printf("Failed to stream %s export: %v", req.Entity, err)
+		}
+	})
+}
+
+func (h *TransferHandler) Import(c fiber.Ctx) error {
+	var req struct {
+		Entity string `query:"entity" validate:"required,oneof=cats missions targets"`
+		Format string `query:"format" validate:"required,oneof=csv json ndjson"`
+	}
+	if err := c.Bind().Query(&req); err != nil {
+		return err
+	}
+
+	body := c.Request().BodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+
+	result, err := h.service.Import(c.Context(), service.ImportInput{
+		Entity: service.TransferEntity(req.Entity),
+		Format: service.TransferFormat(req.Format),
+		Reader: body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&result)
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Attachment struct {
+	ID          uuid.UUID `json:"id"`
+	OwnerType   OwnerType `json:"owner_type" db:"owner_type"`
+	OwnerID     uuid.UUID `json:"owner_id" db:"owner_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"checksum"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	UploadedBy  *string   `json:"uploaded_by" db:"uploaded_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	DownloadURL string    `json:"download_url" db:"-"`
+}
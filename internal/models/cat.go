@@ -1,6 +1,11 @@
 package models
 
 import (
+	"slices"
+	"time"
+
+	"sca/pkg/money"
+
 	"github.com/google/uuid"
 )
 
@@ -9,10 +14,103 @@ type Breed struct {
 	Name string `json:"name"`
 }
 
+type CatStatus string
+
+const (
+	CatActive  CatStatus = "active"
+	CatOnLeave CatStatus = "on_leave"
+	CatRetired CatStatus = "retired"
+)
+
 type Cat struct {
-	ID                uuid.UUID `json:"id"`
+	ID                uuid.UUID   `json:"id"`
+	Name              string      `json:"name"`
+	YearsOfExperience int         `json:"years_of_experience" db:"years_of_experience"`
+	Breed             string      `json:"breed"`
+	Status            CatStatus   `json:"status"`
+	RetiredAt         *time.Time  `json:"retired_at,omitempty" db:"retired_at"`
+	Salary            money.Money `json:"salary" db:"salary"`
+	Skills            []string    `json:"skills" db:"-"`
+	Leaves            []*CatLeave `json:"leaves,omitempty" db:"-"`
+}
+
+type CatFilter struct {
+	Skills []string
+}
+
+func (f CatFilter) IsZero() bool {
+	return len(f.Skills) == 0
+}
+
+func (c *Cat) AvailableBetween(from, to time.Time) bool {
+	if c.Status != CatActive {
+		return false
+	}
+	for _, l := range c.Leaves {
+		if l.Overlaps(from, to) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Cat) MissingSkills(required []string) []string {
+	missing := []string{}
+	for _, skill := range required {
+		if !slices.Contains(c.Skills, skill) {
+			missing = append(missing, skill)
+		}
+	}
+	return missing
+}
+
+type CatStats struct {
+	CatID             uuid.UUID `json:"cat_id" db:"cat_id"`
 	Name              string    `json:"name"`
-	YearsOfExperience int       `json:"years_of_experience" db:"years_of_experience"`
-	Breed             string    `json:"breed"`
-	Salary            float64   `json:"salary"`
+	Rank              int       `json:"rank,omitempty" db:"-"`
+	MissionsCompleted int       `json:"missions_completed" db:"missions_completed"`
+	MissionsAborted   int       `json:"missions_aborted" db:"missions_aborted"`
+	TargetsCompleted  int       `json:"targets_completed" db:"targets_completed"`
+	AvgTargetSeconds  *float64  `json:"avg_target_seconds" db:"avg_target_seconds"`
+	CountriesCount    int       `json:"countries_count" db:"countries_count"`
+	Countries         []string  `json:"countries,omitempty" db:"-"`
+	OnTimeRate        *float64  `json:"on_time_rate" db:"on_time_rate"`
+}
+
+type CatLeaderboardFilter struct {
+	SortBy string
+	Limit  int
+}
+
+type CatLeave struct {
+	ID       uuid.UUID `json:"id"`
+	CatID    uuid.UUID `json:"cat_id" db:"cat_id"`
+	StartsAt time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt   time.Time `json:"ends_at" db:"ends_at"`
+	Reason   *string   `json:"reason"`
+}
+
+func (l *CatLeave) Overlaps(from, to time.Time) bool {
+	return !l.StartsAt.After(to) && l.EndsAt.After(from)
+}
+
+type SalaryChange struct {
+	ID            uuid.UUID   `json:"id"`
+	CatID         uuid.UUID   `json:"cat_id" db:"cat_id"`
+	Salary        money.Money `json:"salary" db:"salary"`
+	EffectiveFrom time.Time   `json:"effective_from" db:"effective_from"`
+	ApprovedBy    *string     `json:"approved_by" db:"approved_by"`
+	CreatedAt     time.Time   `json:"created_at" db:"created_at"`
+}
+
+type CatWorkload struct {
+	CatID  uuid.UUID `json:"cat_id" db:"cat_id"`
+	Active int       `json:"active" db:"active"`
+}
+
+type CatCountryRecord struct {
+	CatID     uuid.UUID `json:"cat_id" db:"cat_id"`
+	Country   string    `json:"country"`
+	Completed int       `json:"completed"`
+	Total     int       `json:"total"`
 }
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Comment struct {
+	ID        uuid.UUID  `json:"id"`
+	OwnerType OwnerType  `json:"owner_type" db:"owner_type"`
+	OwnerID   uuid.UUID  `json:"owner_id" db:"owner_id"`
+	ParentID  *uuid.UUID `json:"parent_id" db:"parent_id"`
+	Author    string     `json:"author"`
+	Body      string     `json:"body"`
+	Mentions  StringList `json:"mentions"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	EditedAt  *time.Time `json:"edited_at" db:"edited_at"`
+	Replies   []*Comment `json:"replies" db:"-"`
+}
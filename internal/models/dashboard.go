@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+type CatAvailability struct {
+	Total     int `json:"total"`
+	Available int `json:"available"`
+	OnMission int `json:"on_mission" db:"on_mission"`
+	OnLeave   int `json:"on_leave" db:"on_leave"`
+	Retired   int `json:"retired"`
+}
+
+type MissionStatusCount struct {
+	Status MissionStatus `json:"status"`
+	Count  int           `json:"count"`
+}
+
+type CountryCount struct {
+	Country string `json:"country"`
+	Count   int    `json:"count"`
+}
+
+type UnassignedMissions struct {
+	Count     int        `json:"count"`
+	Overdue   int        `json:"overdue"`
+	NextDueAt *time.Time `json:"next_due_at" db:"next_due_at"`
+}
+
+type CompletionTrend struct {
+	Day       time.Time `json:"day"`
+	Completed int       `json:"completed"`
+	Aborted   int       `json:"aborted"`
+	Failed    int       `json:"failed"`
+}
+
+type Dashboard struct {
+	Cats        *CatAvailability      `json:"cats"`
+	Missions    map[MissionStatus]int `json:"missions"`
+	OpenTargets []*CountryCount       `json:"open_targets"`
+	Unassigned  *UnassignedMissions   `json:"unassigned_missions"`
+	Trends      []*CompletionTrend    `json:"completion_trends"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TargetOutcome string
+
+const (
+	OutcomeAchieved TargetOutcome = "achieved"
+	OutcomePartial  TargetOutcome = "partial"
+	OutcomeMissed   TargetOutcome = "missed"
+)
+
+const (
+	MinCatRating = 1
+	MaxCatRating = 5
+)
+
+type MissionDebrief struct {
+	MissionID      uuid.UUID         `json:"mission_id" db:"mission_id"`
+	CatID          *uuid.UUID        `json:"cat_id" db:"cat_id"`
+	CatRating      int               `json:"cat_rating" db:"cat_rating"`
+	LessonsLearned string            `json:"lessons_learned" db:"lessons_learned"`
+	Author         string            `json:"author"`
+	CreatedAt      time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt      *time.Time        `json:"updated_at" db:"updated_at"`
+	Outcomes       []*DebriefOutcome `json:"outcomes" db:"-"`
+}
+
+type DebriefOutcome struct {
+	MissionID uuid.UUID     `json:"-" db:"mission_id"`
+	TargetID  uuid.UUID     `json:"target_id" db:"target_id"`
+	Outcome   TargetOutcome `json:"outcome"`
+	Notes     string        `json:"notes"`
+}
@@ -1,12 +1,107 @@
 package models
 
 import (
+	"slices"
+	"time"
+
+	"sca/pkg/money"
+
 	"github.com/google/uuid"
 )
 
+type MissionStatus string
+
+const (
+	MissionDraft      MissionStatus = "draft"
+	MissionAssigned   MissionStatus = "assigned"
+	MissionInProgress MissionStatus = "in_progress"
+	MissionCompleted  MissionStatus = "completed"
+	MissionAborted    MissionStatus = "aborted"
+	MissionFailed     MissionStatus = "failed"
+)
+
+var missionTransitions = map[MissionStatus][]MissionStatus{
+	MissionDraft:      {MissionAssigned, MissionAborted},
+	MissionAssigned:   {MissionDraft, MissionInProgress, MissionCompleted, MissionAborted},
+	MissionInProgress: {MissionCompleted, MissionAborted, MissionFailed},
+}
+
+func (s MissionStatus) CanTransitionTo(next MissionStatus) bool {
+	for _, allowed := range missionTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s MissionStatus) IsFinal() bool {
+	return s == MissionCompleted || s == MissionAborted || s == MissionFailed
+}
+
+func (s MissionStatus) AutoCompletes(targetsComplete []bool) bool {
+	if s != MissionAssigned && s != MissionInProgress {
+		return false
+	}
+	return len(targetsComplete) > 0 && !slices.Contains(targetsComplete, false)
+}
+
+const (
+	MinMissionPriority     = 1
+	DefaultMissionPriority = 3
+	MaxMissionPriority     = 5
+	MaxMissionTargets      = 3
+)
+
 type Mission struct {
-	ID       uuid.UUID  `json:"id"`
-	Complete bool       `json:"complete"`
-	CatId    *uuid.UUID `json:"cat_id" db:"cat_id"`
-	Targets  []*Target  `json:"targets"`
+	ID        uuid.UUID     `json:"id"`
+	Status    MissionStatus `json:"status"`
+	Priority  int           `json:"priority"` // 1 is the least urgent, 5 the most urgent
+	DueAt     *time.Time    `json:"due_at" db:"due_at"`
+	OverdueAt *time.Time    `json:"overdue_at" db:"overdue_at"`
+	Budget    money.Money   `json:"budget" db:"budget"`
+	CatId     *uuid.UUID    `json:"cat_id" db:"cat_id"`
+	Targets   []*Target     `json:"targets"`
+}
+
+type MissionFilter struct {
+	Status    MissionStatus
+	Priority  int
+	Overdue   *bool
+	DueBefore time.Time
+	DueAfter  time.Time
+	SortBy    string
+	Order     string
+}
+
+func (f MissionFilter) IsZero() bool {
+	return f.Status == "" && f.Priority == 0 && f.Overdue == nil &&
+		f.DueBefore.IsZero() && f.DueAfter.IsZero() && f.SortBy == ""
+}
+
+type MissionTransition struct {
+	ID         uuid.UUID      `json:"id"`
+	MissionID  uuid.UUID      `json:"mission_id" db:"mission_id"`
+	FromStatus *MissionStatus `json:"from_status" db:"from_status"`
+	ToStatus   MissionStatus  `json:"to_status" db:"to_status"`
+	Reason     *string        `json:"reason" db:"reason"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+}
+
+type MissionAssignment struct {
+	ID        uuid.UUID  `json:"id"`
+	MissionID uuid.UUID  `json:"mission_id" db:"mission_id"`
+	FromCatId *uuid.UUID `json:"from_cat_id" db:"from_cat_id"`
+	ToCatId   *uuid.UUID `json:"to_cat_id" db:"to_cat_id"`
+	Reason    *string    `json:"reason" db:"reason"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
+
+type MissionExpense struct {
+	ID          uuid.UUID   `json:"id"`
+	MissionID   uuid.UUID   `json:"mission_id" db:"mission_id"`
+	Description string      `json:"description"`
+	Amount      money.Money `json:"amount" db:"amount"`
+	IncurredAt  time.Time   `json:"incurred_at" db:"incurred_at"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,80 @@
+package models
+
+import "testing"
+
+func TestMissionStatusCanTransitionTo(t *testing.T) {
+	statuses := []MissionStatus{MissionDraft, MissionAssigned, MissionInProgress, MissionCompleted, MissionAborted, MissionFailed}
+	allowed := map[[2]MissionStatus]bool{
+		{MissionDraft, MissionAssigned}:       true,
+		{MissionDraft, MissionAborted}:        true,
+		{MissionAssigned, MissionDraft}:       true,
+		{MissionAssigned, MissionInProgress}:  true,
+		{MissionAssigned, MissionCompleted}:   true,
+		{MissionAssigned, MissionAborted}:     true,
+		{MissionInProgress, MissionCompleted}: true,
+		{MissionInProgress, MissionAborted}:   true,
+		{MissionInProgress, MissionFailed}:    true,
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			want := allowed[[2]MissionStatus{from, to}]
+			if got := from.CanTransitionTo(to); got != want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+
+	if MissionStatus("unknown").CanTransitionTo(MissionDraft) {
+		t.Error("unknown status should not transition anywhere")
+	}
+}
+
+func TestMissionStatusIsFinal(t *testing.T) {
+	tests := []struct {
+		status MissionStatus
+		want   bool
+	}{
+		{MissionDraft, false},
+		{MissionAssigned, false},
+		{MissionInProgress, false},
+		{MissionCompleted, true},
+		{MissionAborted, true},
+		{MissionFailed, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.IsFinal(); got != tt.want {
+			t.Errorf("%s.IsFinal() = %v, want %v", tt.status, got, tt.want)
+		}
+		if tt.want && len(missionTransitions[tt.status]) > 0 {
+			t.Errorf("final status %s has outgoing transitions", tt.status)
+		}
+	}
+}
+
+func TestMissionStatusAutoCompletes(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   MissionStatus
+		complete []bool
+		want     bool
+	}{
+		{"assigned with all targets complete", MissionAssigned, []bool{true, true}, true},
+		{"in progress with all targets complete", MissionInProgress, []bool{true}, true},
+		{"in progress with an open target", MissionInProgress, []bool{true, false}, false},
+		{"in progress without targets", MissionInProgress, nil, false},
+		{"draft with all targets complete", MissionDraft, []bool{true, true}, false},
+		{"completed", MissionCompleted, []bool{true}, false},
+		{"aborted", MissionAborted, []bool{true}, false},
+		{"failed", MissionFailed, []bool{true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.AutoCompletes(tt.complete); got != tt.want {
+				t.Errorf("%s.AutoCompletes(%v) = %v, want %v", tt.status, tt.complete, got, tt.want)
+			}
+		})
+	}
+}
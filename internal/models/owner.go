@@ -0,0 +1,8 @@
+package models
+
+type OwnerType string
+
+const (
+	OwnerTarget  OwnerType = "target"
+	OwnerMission OwnerType = "mission"
+)
@@ -1,14 +1,35 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type Target struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      string     `json:"name"`
-	Country   string     `json:"country"`
-	Notes     string     `json:"notes"`
-	Complete  bool       `json:"complete"`
-	MissionID *uuid.UUID `json:"mission_id" db:"mission_id"`
+	ID             uuid.UUID  `json:"id"`
+	Name           string     `json:"name"`
+	Country        string     `json:"country"`
+	Latitude       *float64   `json:"latitude"`
+	Longitude      *float64   `json:"longitude"`
+	LastSeenAt     *time.Time `json:"last_seen_at" db:"last_seen_at"`
+	Notes          string     `json:"notes"`
+	Complete       bool       `json:"complete"`
+	MissionID      *uuid.UUID `json:"mission_id" db:"mission_id"`
+	Position       int        `json:"position"`
+	RequiredSkills []string   `json:"required_skills" db:"-"`
+}
+
+type NearbyTarget struct {
+	Target
+	DistanceKm float64 `json:"distance_km" db:"distance_km"`
+}
+
+type NoteRevision struct {
+	ID        uuid.UUID `json:"id"`
+	TargetID  uuid.UUID `json:"target_id" db:"target_id"`
+	Version   int       `json:"version"`
+	Notes     string    `json:"notes"`
+	Author    *string   `json:"author"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
 }
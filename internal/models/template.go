@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"sca/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type MissionTemplate struct {
+	ID        uuid.UUID         `json:"id"`
+	Name      string            `json:"name"`
+	Priority  int               `json:"priority"`
+	Budget    money.Money       `json:"budget" db:"budget"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+	Targets   []*TemplateTarget `json:"targets" db:"-"`
+}
+
+type TemplateTarget struct {
+	ID             uuid.UUID  `json:"id"`
+	TemplateID     uuid.UUID  `json:"template_id" db:"template_id"`
+	Position       int        `json:"position"`
+	Name           string     `json:"name"`
+	Country        string     `json:"country"`
+	Notes          string     `json:"notes"`
+	RequiredSkills StringList `json:"required_skills" db:"required_skills"`
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/blob"
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	sniffLength         = 512
+	maxFilenameLength   = 255
+	defaultMaxFileSize  = 10 << 20
+	defaultDownloadTTL  = 15 * time.Minute
+	attachmentKeyPrefix = "attachments"
+)
+
+var defaultAllowedTypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+type AttachmentOptions struct {
+	MaxSize      int64
+	AllowedTypes []string
+	SigningKey   string
+	DownloadTTL  time.Duration
+}
+
+type UploadAttachmentInput struct {
+	OwnerType  models.OwnerType
+	OwnerId    uuid.UUID
+	Filename   string
+	Size       int64
+	Content    io.Reader
+	UploadedBy *string
+}
+
+type DownloadAttachmentInput struct {
+	ID        uuid.UUID
+	Expires   int64
+	Signature string
+}
+
+type AttachmentService interface {
+	Upload(ctx context.Context, input UploadAttachmentInput) (*models.Attachment, error)
+	List(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Attachment, error)
+	Download(ctx context.Context, input DownloadAttachmentInput) (*models.Attachment, io.ReadCloser, error)
+	Delete(ctx context.Context, ownerType models.OwnerType, ownerId, id uuid.UUID) error
+}
+
+type AttachmentServiceImpl struct {
+	store        storage.AttachmentStorage
+	missionStore storage.MissionStorage
+	targetStore  storage.TargetStorage
+	blobs        blob.Store
+	options      AttachmentOptions
+}
+
+func NewAttachmentService(store storage.AttachmentStorage, missionStore storage.MissionStorage, targetStore storage.TargetStorage, blobs blob.Store, options AttachmentOptions) *AttachmentServiceImpl {
+	if options.MaxSize <= 0 {
+		options.MaxSize = defaultMaxFileSize
+	}
+	if len(options.AllowedTypes) == 0 {
+		options.AllowedTypes = defaultAllowedTypes
+	}
+	if options.DownloadTTL <= 0 {
+		options.DownloadTTL = defaultDownloadTTL
+	}
+
+	return &AttachmentServiceImpl{
+		store:        store,
+		missionStore: missionStore,
+		targetStore:  targetStore,
+		blobs:        blobs,
+		options:      options,
+	}
+}
+
+func (s *AttachmentServiceImpl) Upload(ctx context.Context, input UploadAttachmentInput) (*models.Attachment, error) {
+	if err := s.checkOwner(ctx, input.OwnerType, input.OwnerId); err != nil {
+		return nil, err
+	}
+	if input.Size <= 0 {
+		return nil, errors.ErrBadRequest{Msg: "Cannot upload attachment: file is empty"}
+	}
+	if input.Size > s.options.MaxSize {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Cannot upload attachment: file exceeds %d bytes", s.options.MaxSize)}
+	}
+
+	content := bufio.NewReaderSize(io.LimitReader(input.Content, input.Size), sniffLength)
+	head, err := content.Peek(sniffLength)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	contentType := http.DetectContentType(head)
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || !slices.Contains(s.options.AllowedTypes, mediaType) {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Cannot upload attachment: content type %s is not allowed", contentType)}
+	}
+
+	attachment := &models.Attachment{
+		ID:          uuid.New(),
+		OwnerType:   input.OwnerType,
+		OwnerID:     input.OwnerId,
+		Filename:    sanitizeFilename(input.Filename),
+		ContentType: contentType,
+		Size:        input.Size,
+		UploadedBy:  input.UploadedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+	attachment.StorageKey = fmt.Sprintf("%s/%s/%s/%s", attachmentKeyPrefix, attachment.OwnerType, attachment.OwnerID, attachment.ID)
+
+	hash := sha256.New()
+	err = s.blobs.Put(ctx, attachment.StorageKey, io.TeeReader(content, hash), attachment.Size, attachment.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	err = s.store.Create(ctx, attachment)
+	if err != nil {
+		if delErr := s.blobs.Delete(ctx, attachment.StorageKey); delErr != nil {
+			log.Printf("Failed to delete orphaned blob %s: %v", attachment.StorageKey, delErr)
+		}
+		return nil, err
+	}
+
+	attachment.DownloadURL = s.downloadURL(attachment.ID, time.Now())
+
+	return attachment, nil
+}
+
+func (s *AttachmentServiceImpl) List(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Attachment, error) {
+	if err := s.checkOwner(ctx, ownerType, ownerId); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.store.ByOwner(ctx, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, a := range attachments {
+		a.DownloadURL = s.downloadURL(a.ID, now)
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentServiceImpl) Download(ctx context.Context, input DownloadAttachmentInput) (*models.Attachment, io.ReadCloser, error) {
+	if time.Now().Unix() > input.Expires {
+		return nil, nil, errors.ErrForbidden{Msg: "Download link has expired"}
+	}
+	expected := s.sign(input.ID, input.Expires)
+	if !hmac.Equal([]byte(expected), []byte(input.Signature)) {
+		return nil, nil, errors.ErrForbidden{Msg: "Invalid download signature"}
+	}
+
+	attachment, err := s.store.ById(ctx, input.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkOwner(ctx, attachment.OwnerType, attachment.OwnerID); err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+func (s *AttachmentServiceImpl) Delete(ctx context.Context, ownerType models.OwnerType, ownerId, id uuid.UUID) error {
+	attachment, err := s.store.ById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.OwnerType != ownerType || attachment.OwnerID != ownerId {
+		return errors.ErrNotFound{Msg: "Attachment not found"}
+	}
+
+	err = s.store.Delete(ctx, attachment.ID)
+	if err != nil {
+		return err
+	}
+
+	deleteBlobs(ctx, s.blobs, []string{attachment.StorageKey})
+	return nil
+}
+
+func deleteBlobs(ctx context.Context, blobs blob.Store, keys []string) {
+	for _, key := range keys {
+		if err := blobs.Delete(ctx, key); err != nil {
+			log.Printf("Failed to delete blob %s: %v", key, err)
+		}
+	}
+}
+
+func (s *AttachmentServiceImpl) checkOwner(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) error {
+	switch ownerType {
+	case models.OwnerMission:
+		_, err := s.missionStore.ById(ctx, ownerId)
+		return err
+	case models.OwnerTarget:
+		_, err := s.targetStore.ById(ctx, ownerId)
+		return err
+	default:
+		return errors.ErrBadRequest{Msg: fmt.Sprintf("Unknown attachment owner: %s", ownerType)}
+	}
+}
+
+func (s *AttachmentServiceImpl) downloadURL(id uuid.UUID, now time.Time) string {
+	expires := now.Add(s.options.DownloadTTL).Unix()
+	return fmt.Sprintf("/attachments/%s/download?expires=%d&signature=%s", id, expires, s.sign(id, expires))
+}
+
+func (s *AttachmentServiceImpl) sign(id uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(s.options.SigningKey))
+	mac.Write([]byte(id.String() + "." + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func sanitizeFilename(name string) string {
+	name = filepath.Base(filepath.Clean("/" + name))
+	if name == "/" || name == "." {
+		name = "file"
+	}
+	if runes := []rune(name); len(runes) > maxFilenameLength {
+		name = string(runes[len(runes)-maxFilenameLength:])
+	}
+	return name
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"sca/internal/storage"
+	"sca/pkg/blob"
+	"sca/pkg/cache"
+	"sca/pkg/errors"
+)
+
+const (
+	BackupFormatVersion = 1
+
+	backupManifestName = "manifest.json"
+	backupTablesDir    = "tables/"
+	backupBlobsDir     = "blobs/"
+)
+
+var (
+	backupTables = []string{
+		"cats",
+		"cat_leaves",
+		"cat_salary_changes",
+		"cat_skills",
+		"missions",
+		"mission_transitions",
+		"mission_assignments",
+		"mission_expenses",
+		"targets",
+		"target_note_revisions",
+		"target_skills",
+		"mission_templates",
+		"mission_template_targets",
+		"attachments",
+		"comments",
+		"mission_debriefs",
+		"mission_debrief_outcomes",
+	}
+	backupCacheKeys = []string{"cats", "missions", "targets", "templates"}
+)
+
+type BackupTable struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+type BackupManifest struct {
+	FormatVersion int            `json:"format_version"`
+	SchemaVersion uint           `json:"schema_version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Tables        []*BackupTable `json:"tables"`
+	Blobs         int            `json:"blobs"`
+}
+
+type BackupService interface {
+	Backup(ctx context.Context, w io.Writer) (*BackupManifest, error)
+	Restore(ctx context.Context, r io.Reader) (*BackupManifest, error)
+}
+
+type BackupServiceImpl struct {
+	store storage.BackupStorage
+	blobs blob.Store
+	cache cache.Cache
+}
+
+func NewBackupService(store storage.BackupStorage, blobs blob.Store, cache cache.Cache) *BackupServiceImpl {
+	return &BackupServiceImpl{
+		store: store,
+		blobs: blobs,
+		cache: cache,
+	}
+}
+
+type backupBlob struct {
+	key         string
+	size        int64
+	contentType string
+}
+
+func (s *BackupServiceImpl) Backup(ctx context.Context, w io.Writer) (*BackupManifest, error) {
+	version, dirty, err := s.store.SchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if dirty {
+		return nil, errors.ErrConflict{Msg: fmt.Sprintf("Cannot back up: schema version %d is dirty", version)}
+	}
+
+	dir, err := os.MkdirTemp("", "sca-backup-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	manifest := &BackupManifest{
+		FormatVersion: BackupFormatVersion,
+		SchemaVersion: version,
+		CreatedAt:     time.Now().UTC(),
+		Tables:        make([]*BackupTable, len(backupTables)),
+	}
+	files := make(map[string]*os.File, len(backupTables))
+	encoders := make(map[string]*json.Encoder, len(backupTables))
+	for i, table := range backupTables {
+		manifest.Tables[i] = &BackupTable{Name: table}
+		file, err := os.Create(filepath.Join(dir, table+".ndjson"))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		files[table] = file
+		encoders[table] = json.NewEncoder(file)
+	}
+
+	var blobs []backupBlob
+	err = s.store.Dump(ctx, backupTables, func(table string, row map[string]any) error {
+		if table == "attachments" {
+			b, err := attachmentBlob(row)
+			if err != nil {
+				return err
+			}
+			blobs = append(blobs, b)
+		}
+		manifest.Tables[slices.Index(backupTables, table)].Rows++
+		return encoders[table].Encode(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	manifest.Blobs = len(blobs)
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBackupEntry(archive, backupManifestName, int64(len(data)), manifest.CreatedAt, strings.NewReader(string(data))); err != nil {
+		return nil, err
+	}
+
+	for _, table := range backupTables {
+		file := files[table]
+		info, err := file.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := writeBackupEntry(archive, backupTablesDir+table+".ndjson", info.Size(), manifest.CreatedAt, file); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, b := range blobs {
+		if err := s.backupBlob(ctx, archive, b, manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (s *BackupServiceImpl) backupBlob(ctx context.Context, archive *tar.Writer, b backupBlob, modTime time.Time) error {
+	r, err := s.blobs.Get(ctx, b.key)
+	if err != nil {
+		return fmt.Errorf("read blob %s: %w", b.key, err)
+	}
+	defer r.Close()
+
+	if err := writeBackupEntry(archive, backupBlobsDir+b.key, b.size, modTime, r); err != nil {
+		return fmt.Errorf("write blob %s: %w", b.key, err)
+	}
+	return nil
+}
+
+func writeBackupEntry(archive *tar.Writer, name string, size int64, modTime time.Time, r io.Reader) error {
+	err := archive.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o644,
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return err
+	}
+	n, err := io.Copy(archive, r)
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return fmt.Errorf("%s: expected %d bytes, got %d", name, size, n)
+	}
+	return nil
+}
+
+func (s *BackupServiceImpl) Restore(ctx context.Context, r io.Reader) (*BackupManifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup archive: %v", err)}
+	}
+	defer gz.Close()
+	archive := tar.NewReader(gz)
+
+	manifest, err := readBackupManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRestorable(ctx, manifest); err != nil {
+		return nil, err
+	}
+
+	expected := make(map[string]int, len(manifest.Tables))
+	for _, t := range manifest.Tables {
+		expected[t.Name] = t.Rows
+	}
+
+	restored := map[string]int{}
+	blobs := map[string]backupBlob{}
+	var written []string
+	err = s.store.Load(ctx, func(insert func(table string, row map[string]any) error) error {
+		for {
+			header, err := archive.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup archive: %v", err)}
+			}
+
+			switch {
+			case strings.HasPrefix(header.Name, backupTablesDir):
+				table := strings.TrimSuffix(strings.TrimPrefix(header.Name, backupTablesDir), ".ndjson")
+				if _, ok := expected[table]; !ok {
+					return errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup archive: table %s is not in the manifest", table)}
+				}
+				err = restoreTable(archive, table, func(row map[string]any) error {
+					if table == "attachments" {
+						b, err := attachmentBlob(row)
+						if err != nil {
+							return err
+						}
+						blobs[b.key] = b
+					}
+					restored[table]++
+					return insert(table, row)
+				})
+			case strings.HasPrefix(header.Name, backupBlobsDir):
+				key := strings.TrimPrefix(header.Name, backupBlobsDir)
+				b, ok := blobs[key]
+				if !ok {
+					return errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup archive: blob %s has no attachment", key)}
+				}
+				err = s.blobs.Put(ctx, key, archive, header.Size, b.contentType)
+				if err == nil {
+					written = append(written, key)
+				}
+			default:
+				err = errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup archive: unexpected entry %s", header.Name)}
+			}
+			if err != nil {
+				return fmt.Errorf("restore %s: %w", header.Name, err)
+			}
+		}
+
+		for table, rows := range expected {
+			if restored[table] != rows {
+				return errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup archive: expected %d rows in %s, found %d", rows, table, restored[table])}
+			}
+		}
+		if len(written) != manifest.Blobs {
+			return errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup archive: expected %d blobs, found %d", manifest.Blobs, len(written))}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, key := range written {
+			if delErr := s.blobs.Delete(context.Background(), key); delErr != nil {
+				log.Printf("Failed to remove restored blob %s: %v", key, delErr)
+			}
+		}
+		return nil, err
+	}
+
+	for _, key := range backupCacheKeys {
+		_ = s.cache.Del(ctx, key)
+	}
+	return manifest, nil
+}
+
+func readBackupManifest(archive *tar.Reader) (*BackupManifest, error) {
+	header, err := archive.Next()
+	if err != nil || header.Name != backupManifestName {
+		return nil, errors.ErrBadRequest{Msg: "Invalid backup archive: missing manifest"}
+	}
+
+	var manifest BackupManifest
+	if err := json.NewDecoder(archive).Decode(&manifest); err != nil {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup manifest: %v", err)}
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > BackupFormatVersion {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Unsupported backup format version %d (supported up to %d)", manifest.FormatVersion, BackupFormatVersion)}
+	}
+	for _, t := range manifest.Tables {
+		if !slices.Contains(backupTables, t.Name) {
+			return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid backup manifest: unknown table %s", t.Name)}
+		}
+	}
+	return &manifest, nil
+}
+
+func (s *BackupServiceImpl) checkRestorable(ctx context.Context, manifest *BackupManifest) error {
+	version, dirty, err := s.store.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot restore: schema version %d is dirty", version)}
+	}
+	if version != manifest.SchemaVersion {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot restore: backup was taken at schema version %d, database is at %d", manifest.SchemaVersion, version)}
+	}
+
+	counts, err := s.store.Counts(ctx, backupTables)
+	if err != nil {
+		return err
+	}
+	for _, table := range backupTables {
+		if counts[table] > 0 {
+			return errors.ErrConflict{Msg: fmt.Sprintf("Cannot restore: database is not empty (%s has %d rows)", table, counts[table])}
+		}
+	}
+	return nil
+}
+
+func restoreTable(r io.Reader, table string, insert func(row map[string]any) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineLen)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		dec := json.NewDecoder(strings.NewReader(data))
+		dec.UseNumber()
+		row := map[string]any{}
+		if err := dec.Decode(&row); err != nil {
+			return errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid row %d in %s: %v", line, table, err)}
+		}
+		if err := insert(row); err != nil {
+			return fmt.Errorf("row %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+func attachmentBlob(row map[string]any) (backupBlob, error) {
+	key, _ := row["storage_key"].(string)
+	contentType, _ := row["content_type"].(string)
+	if key == "" || strings.Contains(key, "..") {
+		return backupBlob{}, errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid attachment storage key %q", key)}
+	}
+
+	size, err := strconv.ParseInt(fmt.Sprint(row["size"]), 10, 64)
+	if err != nil || size < 0 {
+		return backupBlob{}, errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid attachment size %v", row["size"])}
+	}
+
+	return backupBlob{key: key, size: size, contentType: contentType}, nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"fmt"
+
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const maxBulkItems = 100
+
+type BulkMode string
+
+const (
+	BulkAtomic     BulkMode = "atomic"
+	BulkBestEffort BulkMode = "best_effort"
+)
+
+type BulkItemStatus string
+
+const (
+	BulkItemCreated    BulkItemStatus = "created"
+	BulkItemFailed     BulkItemStatus = "failed"
+	BulkItemRolledBack BulkItemStatus = "rolled_back"
+)
+
+type BulkItemResult struct {
+	Index  int            `json:"index"`
+	Status BulkItemStatus `json:"status"`
+	ID     *uuid.UUID     `json:"id,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+type BulkResult struct {
+	Mode    BulkMode          `json:"mode"`
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Items   []*BulkItemResult `json:"items"`
+}
+
+func checkBulkSize(n int) error {
+	if n == 0 {
+		return errors.ErrBadRequest{Msg: "Bulk request has no items"}
+	}
+	if n > maxBulkItems {
+		return errors.ErrBadRequest{Msg: fmt.Sprintf("Bulk request must not exceed %d items", maxBulkItems)}
+	}
+	return nil
+}
+
+func newBulkResult(mode BulkMode, n int) *BulkResult {
+	items := make([]*BulkItemResult, n)
+	for i := range items {
+		items[i] = &BulkItemResult{Index: i}
+	}
+	return &BulkResult{Mode: mode, Items: items}
+}
+
+func (r *BulkResult) fail(i int, err error) {
+	r.Items[i].Status = BulkItemFailed
+	r.Items[i].Error = err.Error()
+	r.Failed++
+}
+
+func (r *BulkResult) create(i int, id uuid.UUID) {
+	r.Items[i].Status = BulkItemCreated
+	r.Items[i].ID = &id
+	r.Created++
+}
+
+func (r *BulkResult) rollBack() {
+	for _, item := range r.Items {
+		if item.Status != BulkItemFailed {
+			item.Status = BulkItemRolledBack
+			item.ID = nil
+		}
+	}
+	r.Created = 0
+}
+
+func (r *BulkResult) apply(indexes []int, ids []uuid.UUID, errs []error) {
+	for j, i := range indexes {
+		if errs[j] != nil {
+			r.fail(i, errs[j])
+		} else {
+			r.create(i, ids[j])
+		}
+	}
+	if r.Mode == BulkAtomic && r.Failed > 0 {
+		r.rollBack()
+	}
+}
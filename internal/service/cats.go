@@ -2,28 +2,65 @@ package service
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"sca/internal/models"
 	"sca/internal/storage"
 	"sca/pkg/cache"
+	"sca/pkg/errors"
+	"sca/pkg/money"
 
 	"github.com/google/uuid"
 )
 
+const defaultLeaderboardLimit = 10
+
 type CreateCatInput struct {
+	ID                uuid.UUID
 	Name              string
 	YearsOfExperience int
 	Breed             string
-	Salary            float64
+	Salary            money.Money
+	Skills            []string
+}
+
+type BulkCreateCatsInput struct {
+	Mode    BulkMode
+	Items   []CreateCatInput
+	Invalid map[int]error
+}
+
+type UpdateCatInput struct {
+	ID            uuid.UUID
+	Salary        money.Money
+	EffectiveFrom *time.Time
+	ApprovedBy    string
+}
+
+type AddLeaveInput struct {
+	CatId    uuid.UUID
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   *string
 }
 
 type CatService interface {
 	Create(ctx context.Context, input CreateCatInput) (*models.Cat, error)
+	BulkCreate(ctx context.Context, input BulkCreateCatsInput) (*BulkResult, error)
 	ById(ctx context.Context, id uuid.UUID) (*models.Cat, error)
-	All(ctx context.Context) ([]*models.Cat, error)
-	Update(ctx context.Context, id uuid.UUID, salayry float64) (*models.Cat, error)
+	All(ctx context.Context, filter models.CatFilter) ([]*models.Cat, error)
+	Update(ctx context.Context, input UpdateCatInput) (*models.Cat, error)
 	Delete(ctx context.Context, id uuid.UUID) error
+	UpdateStatus(ctx context.Context, id uuid.UUID, status models.CatStatus) (*models.Cat, error)
+	Available(ctx context.Context, from, to time.Time) ([]*models.Cat, error)
+	AddLeave(ctx context.Context, input AddLeaveInput) (*models.CatLeave, error)
+	Leaves(ctx context.Context, id uuid.UUID) ([]*models.CatLeave, error)
+	DeleteLeave(ctx context.Context, catId, leaveId uuid.UUID) error
+	SalaryHistory(ctx context.Context, id uuid.UUID) ([]*models.SalaryChange, error)
+	UpdateSkills(ctx context.Context, id uuid.UUID, skills []string) (*models.Cat, error)
+	Stats(ctx context.Context, id uuid.UUID) (*models.CatStats, error)
+	Leaderboard(ctx context.Context, filter models.CatLeaderboardFilter) ([]*models.CatStats, error)
 }
 
 type CatServiceImpl struct {
@@ -42,11 +79,13 @@ func (s *CatServiceImpl) Create(ctx context.Context, input CreateCatInput) (*mod
 	const cacheKey = "cats"
 
 	cat := &models.Cat{
-		ID:                uuid.New(),
+		ID:                newId(input.ID),
 		Name:              input.Name,
 		YearsOfExperience: input.YearsOfExperience,
 		Breed:             input.Breed,
+		Status:            models.CatActive,
 		Salary:            input.Salary,
+		Skills:            uniqueSkills(input.Skills),
 	}
 	err := s.store.Create(ctx, cat)
 	if err != nil {
@@ -58,6 +97,53 @@ func (s *CatServiceImpl) Create(ctx context.Context, input CreateCatInput) (*mod
 	return cat, nil
 }
 
+func (s *CatServiceImpl) BulkCreate(ctx context.Context, input BulkCreateCatsInput) (*BulkResult, error) {
+	const cacheKey = "cats"
+
+	if err := checkBulkSize(len(input.Items)); err != nil {
+		return nil, err
+	}
+
+	result := newBulkResult(input.Mode, len(input.Items))
+	cats := make([]*models.Cat, 0, len(input.Items))
+	ids := make([]uuid.UUID, 0, len(input.Items))
+	indexes := make([]int, 0, len(input.Items))
+	for i, item := range input.Items {
+		if err := input.Invalid[i]; err != nil {
+			result.fail(i, err)
+			continue
+		}
+		cat := &models.Cat{
+			ID:                uuid.New(),
+			Name:              item.Name,
+			YearsOfExperience: item.YearsOfExperience,
+			Breed:             item.Breed,
+			Status:            models.CatActive,
+			Salary:            item.Salary,
+			Skills:            uniqueSkills(item.Skills),
+		}
+		cats = append(cats, cat)
+		ids = append(ids, cat.ID)
+		indexes = append(indexes, i)
+	}
+	if input.Mode == BulkAtomic && result.Failed > 0 {
+		result.rollBack()
+		return result, nil
+	}
+
+	errs, err := s.store.CreateMany(ctx, cats, input.Mode == BulkAtomic)
+	if err != nil {
+		return nil, err
+	}
+	result.apply(indexes, ids, errs)
+
+	if result.Created > 0 {
+		_ = s.cache.Del(ctx, cacheKey)
+	}
+
+	return result, nil
+}
+
 func (s *CatServiceImpl) ById(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
 	cat, err := s.store.ById(ctx, id)
 	if err != nil {
@@ -66,14 +152,18 @@ func (s *CatServiceImpl) ById(ctx context.Context, id uuid.UUID) (*models.Cat, e
 	return cat, nil
 }
 
-func (s *CatServiceImpl) All(ctx context.Context) ([]*models.Cat, error) {
+func (s *CatServiceImpl) All(ctx context.Context, filter models.CatFilter) ([]*models.Cat, error) {
 	const cacheKey = "cats"
 
+	if !filter.IsZero() {
+		return s.store.All(ctx, filter)
+	}
+
 	if items, _ := s.cache.Get(ctx, cacheKey); items != nil {
 		return items.([]*models.Cat), nil
 	}
 
-	cats, err := s.store.All(ctx)
+	cats, err := s.store.All(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -83,20 +173,42 @@ func (s *CatServiceImpl) All(ctx context.Context) ([]*models.Cat, error) {
 	return cats, nil
 }
 
-func (s *CatServiceImpl) Update(ctx context.Context, id uuid.UUID, salary float64) (*models.Cat, error) {
+func (s *CatServiceImpl) Update(ctx context.Context, input UpdateCatInput) (*models.Cat, error) {
 	const cacheKey = "cats"
 
-	cat, err := s.ById(ctx, id)
+	cat, err := s.ById(ctx, input.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	cat.Salary = salary
+	now := time.Now().UTC()
+	effectiveFrom := now.Truncate(24 * time.Hour)
+	if input.EffectiveFrom != nil {
+		effectiveFrom = input.EffectiveFrom.UTC().Truncate(24 * time.Hour)
+	}
 
-	err = s.store.Update(ctx, cat)
+	changes, err := s.store.SalaryChanges(ctx, cat.ID)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(changes); n > 0 && effectiveFrom.Before(changes[n-1].EffectiveFrom) {
+		return nil, errors.ErrConflict{Msg: "Cannot update salary: a later salary change already exists"}
+	}
+
+	err = s.store.UpdateSalary(ctx, &models.SalaryChange{
+		ID:            uuid.New(),
+		CatID:         cat.ID,
+		Salary:        input.Salary,
+		EffectiveFrom: effectiveFrom,
+		ApprovedBy:    &input.ApprovedBy,
+		CreatedAt:     now,
+	})
 	if err != nil {
 		return nil, err
 	}
+	if !effectiveFrom.After(now) {
+		cat.Salary = input.Salary
+	}
 
 	_ = s.cache.Del(ctx, cacheKey)
 
@@ -120,3 +232,150 @@ func (s *CatServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 
 	return nil
 }
+
+func (s *CatServiceImpl) UpdateStatus(ctx context.Context, id uuid.UUID, status models.CatStatus) (*models.Cat, error) {
+	const cacheKey = "cats"
+
+	cat, err := s.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if cat.Status == models.CatRetired {
+		return nil, errors.ErrConflict{Msg: "Cannot change status: cat is retired"}
+	}
+
+	cat.Status = status
+	if status == models.CatRetired {
+		now := time.Now().UTC()
+		cat.RetiredAt = &now
+	}
+
+	err = s.store.Update(ctx, cat)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return cat, nil
+}
+
+func (s *CatServiceImpl) Available(ctx context.Context, from, to time.Time) ([]*models.Cat, error) {
+	cats, err := s.store.Available(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return cats, nil
+}
+
+func (s *CatServiceImpl) AddLeave(ctx context.Context, input AddLeaveInput) (*models.CatLeave, error) {
+	cat, err := s.ById(ctx, input.CatId)
+	if err != nil {
+		return nil, err
+	}
+	if cat.Status == models.CatRetired {
+		return nil, errors.ErrConflict{Msg: "Cannot add leave: cat is retired"}
+	}
+	for _, l := range cat.Leaves {
+		if l.Overlaps(input.StartsAt, input.EndsAt) {
+			return nil, errors.ErrConflict{Msg: "Cannot add leave: cat already has leave in this period"}
+		}
+	}
+
+	leave := &models.CatLeave{
+		ID:       uuid.New(),
+		CatID:    cat.ID,
+		StartsAt: input.StartsAt,
+		EndsAt:   input.EndsAt,
+		Reason:   input.Reason,
+	}
+	err = s.store.AddLeave(ctx, leave)
+	if err != nil {
+		return nil, err
+	}
+
+	return leave, nil
+}
+
+func (s *CatServiceImpl) Leaves(ctx context.Context, id uuid.UUID) ([]*models.CatLeave, error) {
+	cat, err := s.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return cat.Leaves, nil
+}
+
+func (s *CatServiceImpl) DeleteLeave(ctx context.Context, catId, leaveId uuid.UUID) error {
+	_, err := s.ById(ctx, catId)
+	if err != nil {
+		return err
+	}
+
+	return s.store.DeleteLeave(ctx, catId, leaveId)
+}
+
+func (s *CatServiceImpl) SalaryHistory(ctx context.Context, id uuid.UUID) ([]*models.SalaryChange, error) {
+	_, err := s.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.store.SalaryChanges(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (s *CatServiceImpl) UpdateSkills(ctx context.Context, id uuid.UUID, skills []string) (*models.Cat, error) {
+	const cacheKey = "cats"
+
+	cat, err := s.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cat.Skills = uniqueSkills(skills)
+	err = s.store.SetSkills(ctx, cat.ID, cat.Skills)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return cat, nil
+}
+
+func uniqueSkills(skills []string) []string {
+	unique := []string{}
+	for _, skill := range skills {
+		if !slices.Contains(unique, skill) {
+			unique = append(unique, skill)
+		}
+	}
+	slices.Sort(unique)
+	return unique
+}
+
+func (s *CatServiceImpl) Stats(ctx context.Context, id uuid.UUID) (*models.CatStats, error) {
+	stats, err := s.store.Stats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (s *CatServiceImpl) Leaderboard(ctx context.Context, filter models.CatLeaderboardFilter) ([]*models.CatStats, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultLeaderboardLimit
+	}
+
+	stats, err := s.store.Leaderboard(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i, st := range stats {
+		st.Rank = i + 1
+	}
+	return stats, nil
+}
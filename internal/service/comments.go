@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/errors"
+	"sca/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+const (
+	EventCommentMention = "comment.mention"
+
+	defaultCommentEditWindow = 15 * time.Minute
+)
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([A-Za-z0-9_][A-Za-z0-9_.-]{2,63})`)
+
+type CommentOptions struct {
+	EditWindow time.Duration
+}
+
+type AddCommentInput struct {
+	OwnerType models.OwnerType
+	OwnerId   uuid.UUID
+	ParentId  *uuid.UUID
+	Author    string
+	Body      string
+}
+
+type EditCommentInput struct {
+	OwnerType models.OwnerType
+	OwnerId   uuid.UUID
+	ID        uuid.UUID
+	Author    string
+	Body      string
+}
+
+type DeleteCommentInput struct {
+	OwnerType models.OwnerType
+	OwnerId   uuid.UUID
+	ID        uuid.UUID
+	Author    string
+}
+
+type CommentService interface {
+	Add(ctx context.Context, input AddCommentInput) (*models.Comment, error)
+	Thread(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Comment, error)
+	Edit(ctx context.Context, input EditCommentInput) (*models.Comment, error)
+	Delete(ctx context.Context, input DeleteCommentInput) error
+}
+
+type CommentServiceImpl struct {
+	store        storage.CommentStorage
+	missionStore storage.MissionStorage
+	targetStore  storage.TargetStorage
+	notifier     notify.Notifier
+	options      CommentOptions
+}
+
+func NewCommentService(store storage.CommentStorage, missionStore storage.MissionStorage, targetStore storage.TargetStorage, notifier notify.Notifier, options CommentOptions) *CommentServiceImpl {
+	if options.EditWindow <= 0 {
+		options.EditWindow = defaultCommentEditWindow
+	}
+
+	return &CommentServiceImpl{
+		store:        store,
+		missionStore: missionStore,
+		targetStore:  targetStore,
+		notifier:     notifier,
+		options:      options,
+	}
+}
+
+func (s *CommentServiceImpl) Add(ctx context.Context, input AddCommentInput) (*models.Comment, error) {
+	if err := s.checkOpen(ctx, input.OwnerType, input.OwnerId, "Cannot add comment"); err != nil {
+		return nil, err
+	}
+	if input.ParentId != nil {
+		if _, err := s.ownComment(ctx, input.OwnerType, input.OwnerId, *input.ParentId); err != nil {
+			return nil, err
+		}
+	}
+
+	comment := &models.Comment{
+		ID:        uuid.New(),
+		OwnerType: input.OwnerType,
+		OwnerID:   input.OwnerId,
+		ParentID:  input.ParentId,
+		Author:    input.Author,
+		Body:      input.Body,
+		Mentions:  mentions(input.Body, input.Author),
+		CreatedAt: time.Now().UTC(),
+		Replies:   []*models.Comment{},
+	}
+	err := s.store.Create(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notifyMentions(ctx, comment, comment.Mentions)
+
+	return comment, nil
+}
+
+func (s *CommentServiceImpl) Thread(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Comment, error) {
+	if err := s.checkOwner(ctx, ownerType, ownerId); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.store.ByOwner(ctx, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := make(map[uuid.UUID]*models.Comment, len(comments))
+	for _, c := range comments {
+		c.Replies = []*models.Comment{}
+		byId[c.ID] = c
+	}
+
+	thread := []*models.Comment{}
+	for _, c := range comments {
+		if c.ParentID != nil {
+			if parent, ok := byId[*c.ParentID]; ok {
+				parent.Replies = append(parent.Replies, c)
+				continue
+			}
+		}
+		thread = append(thread, c)
+	}
+	return thread, nil
+}
+
+func (s *CommentServiceImpl) Edit(ctx context.Context, input EditCommentInput) (*models.Comment, error) {
+	comment, err := s.ownComment(ctx, input.OwnerType, input.OwnerId, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkAuthor(comment, input.Author, "Cannot edit comment"); err != nil {
+		return nil, err
+	}
+	if err := s.checkOpen(ctx, input.OwnerType, input.OwnerId, "Cannot edit comment"); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(comment.CreatedAt) > s.options.EditWindow {
+		return nil, errors.ErrConflict{Msg: fmt.Sprintf("Cannot edit comment: edit window of %s has passed", s.options.EditWindow)}
+	}
+
+	previous := comment.Mentions
+	comment.Body = input.Body
+	comment.Mentions = mentions(input.Body, comment.Author)
+	comment.EditedAt = &now
+
+	err = s.store.Update(ctx, comment)
+	if err != nil {
+		return nil, err
+	}
+
+	added := []string{}
+	for _, m := range comment.Mentions {
+		if !slices.Contains(previous, m) {
+			added = append(added, m)
+		}
+	}
+	s.notifyMentions(ctx, comment, added)
+
+	return comment, nil
+}
+
+func (s *CommentServiceImpl) Delete(ctx context.Context, input DeleteCommentInput) error {
+	comment, err := s.ownComment(ctx, input.OwnerType, input.OwnerId, input.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkAuthor(comment, input.Author, "Cannot delete comment"); err != nil {
+		return err
+	}
+	if err := s.checkOpen(ctx, input.OwnerType, input.OwnerId, "Cannot delete comment"); err != nil {
+		return err
+	}
+
+	replies, err := s.store.CountReplies(ctx, comment.ID)
+	if err != nil {
+		return err
+	}
+	if replies > 0 {
+		return errors.ErrConflict{Msg: "Cannot delete comment: it already has replies"}
+	}
+
+	return s.store.Delete(ctx, comment.ID)
+}
+
+func (s *CommentServiceImpl) ownComment(ctx context.Context, ownerType models.OwnerType, ownerId, id uuid.UUID) (*models.Comment, error) {
+	comment, err := s.store.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.OwnerType != ownerType || comment.OwnerID != ownerId {
+		return nil, errors.ErrNotFound{Msg: "Comment not found"}
+	}
+	return comment, nil
+}
+
+func (s *CommentServiceImpl) checkAuthor(comment *models.Comment, author, prefix string) error {
+	if comment.Author != author {
+		return errors.ErrForbidden{Msg: prefix + ": only the author can change a comment"}
+	}
+	return nil
+}
+
+func (s *CommentServiceImpl) checkOwner(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) error {
+	switch ownerType {
+	case models.OwnerMission:
+		_, err := s.missionStore.ById(ctx, ownerId)
+		return err
+	case models.OwnerTarget:
+		_, err := s.targetStore.ById(ctx, ownerId)
+		return err
+	default:
+		return errors.ErrBadRequest{Msg: fmt.Sprintf("Unknown comment owner: %s", ownerType)}
+	}
+}
+
+func (s *CommentServiceImpl) checkOpen(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID, prefix string) error {
+	switch ownerType {
+	case models.OwnerMission:
+		mission, err := s.missionStore.ById(ctx, ownerId)
+		if err != nil {
+			return err
+		}
+		if mission.Status.IsFinal() {
+			return errors.ErrConflict{Msg: fmt.Sprintf("%s: mission is %s", prefix, mission.Status)}
+		}
+		return nil
+	case models.OwnerTarget:
+		target, err := s.targetStore.ById(ctx, ownerId)
+		if err != nil {
+			return err
+		}
+		return checkTargetEditable(ctx, s.missionStore, target, prefix)
+	default:
+		return errors.ErrBadRequest{Msg: fmt.Sprintf("Unknown comment owner: %s", ownerType)}
+	}
+}
+
+func (s *CommentServiceImpl) notifyMentions(ctx context.Context, comment *models.Comment, handles []string) {
+	if len(handles) == 0 {
+		return
+	}
+
+	err := s.notifier.Notify(ctx, notify.Event{
+		Type:    EventCommentMention,
+		Message: fmt.Sprintf("%s mentioned %d user(s) on %s %s", comment.Author, len(handles), comment.OwnerType, comment.OwnerID),
+		Data: map[string]any{
+			"comment":   comment,
+			"mentioned": handles,
+		},
+		OccurredAt: time.Now().UTC(),
+	})
+	if err != nil {
+		log.Printf("Failed to notify about mentions in comment %s: %v", comment.ID, err)
+	}
+}
+
+func mentions(body, author string) models.StringList {
+	handles := models.StringList{}
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		handle := strings.TrimRight(m[1], ".-")
+		if len(handle) >= 3 && handle != author && !slices.Contains(handles, handle) {
+			handles = append(handles, handle)
+		}
+	}
+	return handles
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/money"
+	"sca/pkg/notify"
+
+	"github.com/google/uuid"
+)
+
+const EventMissionOverBudget = "mission.over_budget"
+
+type AddExpenseInput struct {
+	MissionId   uuid.UUID
+	Description string
+	Amount      money.Money
+	IncurredAt  time.Time
+}
+
+type LaborCost struct {
+	CatId  uuid.UUID   `json:"cat_id"`
+	From   time.Time   `json:"from"`
+	To     time.Time   `json:"to"`
+	Salary money.Money `json:"salary"`
+	Cost   money.Money `json:"cost"`
+}
+
+type MissionCost struct {
+	MissionId  uuid.UUID                `json:"mission_id"`
+	Budget     money.Money              `json:"budget"`
+	Labor      []*LaborCost             `json:"labor"`
+	Expenses   []*models.MissionExpense `json:"expenses"`
+	Totals     []money.Money            `json:"totals"`
+	Remaining  *money.Money             `json:"remaining"`
+	OverBudget bool                     `json:"over_budget"`
+	Warnings   []string                 `json:"warnings"`
+}
+
+type CostService interface {
+	MissionCost(ctx context.Context, id uuid.UUID) (*MissionCost, error)
+	Budgets(ctx context.Context, overBudgetOnly bool) ([]*MissionCost, error)
+	AddExpense(ctx context.Context, input AddExpenseInput) (*models.MissionExpense, error)
+	Expenses(ctx context.Context, missionId uuid.UUID) ([]*models.MissionExpense, error)
+	DeleteExpense(ctx context.Context, missionId, expenseId uuid.UUID) error
+}
+
+type CostServiceImpl struct {
+	missionStore storage.MissionStorage
+	catStore     storage.CatStorage
+	notifier     notify.Notifier
+}
+
+func NewCostService(missionStore storage.MissionStorage, catStore storage.CatStorage, notifier notify.Notifier) *CostServiceImpl {
+	return &CostServiceImpl{
+		missionStore: missionStore,
+		catStore:     catStore,
+		notifier:     notifier,
+	}
+}
+
+func (s *CostServiceImpl) MissionCost(ctx context.Context, id uuid.UUID) (*MissionCost, error) {
+	mission, err := s.missionStore.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.cost(ctx, mission)
+}
+
+func (s *CostServiceImpl) cost(ctx context.Context, mission *models.Mission) (*MissionCost, error) {
+	costs, err := s.costs(ctx, []*models.Mission{mission})
+	if err != nil {
+		return nil, err
+	}
+	return costs[0], nil
+}
+
+func (s *CostServiceImpl) Budgets(ctx context.Context, overBudgetOnly bool) ([]*MissionCost, error) {
+	missions, err := s.missionStore.All(ctx, models.MissionFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	budgeted := []*models.Mission{}
+	for _, mission := range missions {
+		if !mission.Budget.IsZero() {
+			budgeted = append(budgeted, mission)
+		}
+	}
+
+	all, err := s.costs(ctx, budgeted)
+	if err != nil {
+		return nil, err
+	}
+
+	costs := []*MissionCost{}
+	for _, cost := range all {
+		if overBudgetOnly && !cost.OverBudget {
+			continue
+		}
+		costs = append(costs, cost)
+	}
+	return costs, nil
+}
+
+func (s *CostServiceImpl) AddExpense(ctx context.Context, input AddExpenseInput) (*models.MissionExpense, error) {
+	mission, err := s.missionStore.ById(ctx, input.MissionId)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := s.cost(ctx, mission)
+	if err != nil {
+		return nil, err
+	}
+
+	expense := &models.MissionExpense{
+		ID:          uuid.New(),
+		MissionID:   mission.ID,
+		Description: input.Description,
+		Amount:      input.Amount,
+		IncurredAt:  input.IncurredAt,
+		CreatedAt:   time.Now().UTC(),
+	}
+	err = s.missionStore.AddExpense(ctx, expense)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := s.cost(ctx, mission)
+	if err != nil {
+		return nil, err
+	}
+	if after.OverBudget && !before.OverBudget {
+		err = s.notifier.Notify(ctx, notify.Event{
+			Type:       EventMissionOverBudget,
+			Message:    fmt.Sprintf("Mission %s is over budget", mission.ID),
+			Data:       after,
+			OccurredAt: expense.CreatedAt,
+		})
+		if err != nil {
+			log.Printf("Failed to notify about over-budget mission %s: %v", mission.ID, err)
+		}
+	}
+
+	return expense, nil
+}
+
+func (s *CostServiceImpl) Expenses(ctx context.Context, missionId uuid.UUID) ([]*models.MissionExpense, error) {
+	_, err := s.missionStore.ById(ctx, missionId)
+	if err != nil {
+		return nil, err
+	}
+
+	expenses, err := s.missionStore.Expenses(ctx, missionId)
+	if err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (s *CostServiceImpl) DeleteExpense(ctx context.Context, missionId, expenseId uuid.UUID) error {
+	_, err := s.missionStore.ById(ctx, missionId)
+	if err != nil {
+		return err
+	}
+
+	return s.missionStore.DeleteExpense(ctx, missionId, expenseId)
+}
+
+type costData struct {
+	assignments map[uuid.UUID][]*models.MissionAssignment
+	transitions map[uuid.UUID][]*models.MissionTransition
+	expenses    map[uuid.UUID][]*models.MissionExpense
+	salaries    map[uuid.UUID][]*models.SalaryChange
+}
+
+func (s *CostServiceImpl) costs(ctx context.Context, missions []*models.Mission) ([]*MissionCost, error) {
+	ids := make([]uuid.UUID, len(missions))
+	for i, m := range missions {
+		ids[i] = m.ID
+	}
+
+	data := &costData{
+		assignments: make(map[uuid.UUID][]*models.MissionAssignment),
+		transitions: make(map[uuid.UUID][]*models.MissionTransition),
+		expenses:    make(map[uuid.UUID][]*models.MissionExpense),
+		salaries:    make(map[uuid.UUID][]*models.SalaryChange),
+	}
+
+	assignments, err := s.missionStore.AssignmentsByMissions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	catIds := []uuid.UUID{}
+	for _, a := range assignments {
+		data.assignments[a.MissionID] = append(data.assignments[a.MissionID], a)
+		if a.ToCatId != nil {
+			catIds = append(catIds, *a.ToCatId)
+		}
+	}
+
+	transitions, err := s.missionStore.TransitionsByMissions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range transitions {
+		data.transitions[t.MissionID] = append(data.transitions[t.MissionID], t)
+	}
+
+	expenses, err := s.missionStore.ExpensesByMissions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range expenses {
+		data.expenses[e.MissionID] = append(data.expenses[e.MissionID], e)
+	}
+
+	changes, err := s.catStore.SalaryChangesByCats(ctx, catIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range changes {
+		data.salaries[ch.CatID] = append(data.salaries[ch.CatID], ch)
+	}
+
+	now := time.Now().UTC()
+	costs := make([]*MissionCost, len(missions))
+	for i, m := range missions {
+		costs[i] = missionCost(m, data, now)
+	}
+	return costs, nil
+}
+
+func missionCost(mission *models.Mission, data *costData, now time.Time) *MissionCost {
+	cost := &MissionCost{
+		MissionId: mission.ID,
+		Budget:    mission.Budget,
+		Labor:     []*LaborCost{},
+		Expenses:  []*models.MissionExpense{},
+		Totals:    []money.Money{},
+		Warnings:  []string{},
+	}
+
+	end := missionEnd(mission, data.transitions[mission.ID], now)
+
+	assignments := data.assignments[mission.ID]
+	for i, a := range assignments {
+		if a.ToCatId == nil {
+			continue
+		}
+		to := end
+		if i+1 < len(assignments) && assignments[i+1].CreatedAt.Before(end) {
+			to = assignments[i+1].CreatedAt
+		}
+		if !to.After(a.CreatedAt) {
+			continue
+		}
+
+		changes, ok := data.salaries[*a.ToCatId]
+		if !ok {
+			cost.Warnings = append(cost.Warnings, fmt.Sprintf("Cat %s no longer exists, its labor is not counted", *a.ToCatId))
+			continue
+		}
+
+		for _, seg := range salarySegments(changes, a.CreatedAt, to) {
+			for _, amount := range proratedSalary(changes, seg.from, seg.to) {
+				labor := &LaborCost{
+					CatId:  *a.ToCatId,
+					From:   seg.from,
+					To:     seg.to,
+					Salary: seg.salary,
+					Cost:   amount,
+				}
+				cost.Labor = append(cost.Labor, labor)
+				cost.Totals = money.Accumulate(cost.Totals, labor.Cost)
+			}
+		}
+	}
+
+	if expenses, ok := data.expenses[mission.ID]; ok {
+		cost.Expenses = expenses
+	}
+	for _, e := range cost.Expenses {
+		cost.Totals = money.Accumulate(cost.Totals, e.Amount)
+	}
+
+	if mission.Budget.IsZero() {
+		return cost
+	}
+
+	remaining := mission.Budget
+	for _, total := range cost.Totals {
+		if total.Currency != mission.Budget.Currency {
+			cost.Warnings = append(cost.Warnings, fmt.Sprintf("Spend of %s is not compared against the %s budget", total, mission.Budget.Currency))
+			continue
+		}
+		remaining.Amount -= total.Amount
+	}
+	cost.Remaining = &remaining
+	if remaining.Amount < 0 {
+		cost.OverBudget = true
+		cost.Warnings = append(cost.Warnings, fmt.Sprintf("Mission is over budget by %s", money.New(-remaining.Amount, remaining.Currency)))
+	}
+
+	return cost
+}
+
+func missionEnd(mission *models.Mission, transitions []*models.MissionTransition, now time.Time) time.Time {
+	if !mission.Status.IsFinal() {
+		return now
+	}
+	for i := len(transitions) - 1; i >= 0; i-- {
+		if transitions[i].ToStatus.IsFinal() {
+			return transitions[i].CreatedAt
+		}
+	}
+	return now
+}
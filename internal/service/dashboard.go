@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/cache"
+)
+
+const (
+	DefaultTrendDays = 30
+	MaxTrendDays     = 365
+
+	defaultDashboardTTL      = 30 * time.Second
+	defaultDashboardTrendTTL = 5 * time.Minute
+)
+
+var dashboardStatuses = []models.MissionStatus{
+	models.MissionDraft,
+	models.MissionAssigned,
+	models.MissionInProgress,
+	models.MissionCompleted,
+	models.MissionAborted,
+	models.MissionFailed,
+}
+
+type DashboardOptions struct {
+	TTL      time.Duration
+	TrendTTL time.Duration
+}
+
+type DashboardInput struct {
+	Days int
+}
+
+type DashboardService interface {
+	Summary(ctx context.Context, input DashboardInput) (*models.Dashboard, error)
+}
+
+type DashboardServiceImpl struct {
+	store   storage.DashboardStorage
+	cache   cache.Cache
+	options DashboardOptions
+}
+
+func NewDashboardService(store storage.DashboardStorage, cache cache.Cache, options DashboardOptions) *DashboardServiceImpl {
+	if options.TTL <= 0 {
+		options.TTL = defaultDashboardTTL
+	}
+	if options.TrendTTL <= 0 {
+		options.TrendTTL = defaultDashboardTrendTTL
+	}
+
+	return &DashboardServiceImpl{
+		store:   store,
+		cache:   cache,
+		options: options,
+	}
+}
+
+func (s *DashboardServiceImpl) Summary(ctx context.Context, input DashboardInput) (*models.Dashboard, error) {
+	days := input.Days
+	if days <= 0 {
+		days = DefaultTrendDays
+	}
+	days = min(days, MaxTrendDays)
+	now := time.Now().UTC()
+
+	var dashboard models.Dashboard
+	var err error
+	dashboard.Cats, err = cached(ctx, s.cache, "dashboard:cats", s.options.TTL, func() (*models.CatAvailability, error) {
+		return s.store.CatAvailability(ctx, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.Missions, err = cached(ctx, s.cache, "dashboard:missions", s.options.TTL, func() (map[models.MissionStatus]int, error) {
+		counts, err := s.store.MissionCounts(ctx)
+		if err != nil {
+			return nil, err
+		}
+		byStatus := make(map[models.MissionStatus]int, len(dashboardStatuses))
+		for _, status := range dashboardStatuses {
+			byStatus[status] = 0
+		}
+		for _, c := range counts {
+			byStatus[c.Status] = c.Count
+		}
+		return byStatus, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.OpenTargets, err = cached(ctx, s.cache, "dashboard:open_targets", s.options.TTL, func() ([]*models.CountryCount, error) {
+		return s.store.OpenTargets(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.Unassigned, err = cached(ctx, s.cache, "dashboard:unassigned", s.options.TTL, func() (*models.UnassignedMissions, error) {
+		return s.store.Unassigned(ctx, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.Trends, err = cached(ctx, s.cache, fmt.Sprintf("dashboard:trends:%d", days), s.options.TrendTTL, func() ([]*models.CompletionTrend, error) {
+		return s.trends(ctx, now, days)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dashboard, nil
+}
+
+func (s *DashboardServiceImpl) trends(ctx context.Context, now time.Time, days int) ([]*models.CompletionTrend, error) {
+	since := now.Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+	rows, err := s.store.CompletionTrends(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	byDay := make(map[string]*models.CompletionTrend, len(rows))
+	for _, r := range rows {
+		byDay[r.Day.Format(time.DateOnly)] = r
+	}
+
+	trends := make([]*models.CompletionTrend, days)
+	for i := range trends {
+		day := since.AddDate(0, 0, i)
+		if r, ok := byDay[day.Format(time.DateOnly)]; ok {
+			r.Day = day
+			trends[i] = r
+		} else {
+			trends[i] = &models.CompletionTrend{Day: day}
+		}
+	}
+	return trends, nil
+}
+
+func cached[T any](ctx context.Context, c cache.Cache, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	if item, _ := c.Get(ctx, key); item != nil {
+		var data []byte
+		switch v := item.(type) {
+		case T:
+			return v, nil
+		case string:
+			data = []byte(v)
+		case []byte:
+			data = v
+		}
+
+		var value T
+		if data != nil && json.Unmarshal(data, &value) == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	if data, err := json.Marshal(value); err == nil {
+		_ = c.Set(ctx, key, data, ttl)
+	}
+	return value, nil
+}
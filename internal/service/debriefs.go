@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/cache"
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+type DebriefOptions struct {
+	Required bool
+}
+
+type DebriefOutcomeInput struct {
+	TargetId uuid.UUID
+	Outcome  models.TargetOutcome
+	Notes    string
+}
+
+type SubmitDebriefInput struct {
+	MissionId      uuid.UUID
+	CatRating      int
+	LessonsLearned string
+	Author         string
+	Outcomes       []DebriefOutcomeInput
+}
+
+type DebriefReportTarget struct {
+	Target  *models.Target         `json:"target"`
+	Outcome *models.DebriefOutcome `json:"outcome"`
+	Notes   []*models.NoteRevision `json:"notes_history"`
+}
+
+type DebriefReport struct {
+	Mission     *models.Mission             `json:"mission"`
+	Cat         *models.Cat                 `json:"cat"`
+	Debrief     *models.MissionDebrief      `json:"debrief"`
+	Targets     []*DebriefReportTarget      `json:"targets"`
+	Transitions []*models.MissionTransition `json:"transitions"`
+	GeneratedAt time.Time                   `json:"generated_at"`
+}
+
+type DebriefService interface {
+	Submit(ctx context.Context, input SubmitDebriefInput) (*models.MissionDebrief, error)
+	ByMission(ctx context.Context, missionId uuid.UUID) (*models.MissionDebrief, error)
+	Report(ctx context.Context, missionId uuid.UUID) (*DebriefReport, error)
+}
+
+type DebriefServiceImpl struct {
+	store          storage.DebriefStorage
+	missionStore   storage.MissionStorage
+	catStore       storage.CatStorage
+	targetStore    storage.TargetStorage
+	cache          cache.Cache
+	requireDebrief bool
+}
+
+func NewDebriefService(store storage.DebriefStorage, missionStore storage.MissionStorage, catStore storage.CatStorage, targetStore storage.TargetStorage, cache cache.Cache, requireDebrief bool) *DebriefServiceImpl {
+	return &DebriefServiceImpl{
+		store:          store,
+		missionStore:   missionStore,
+		catStore:       catStore,
+		targetStore:    targetStore,
+		cache:          cache,
+		requireDebrief: requireDebrief,
+	}
+}
+
+func (s *DebriefServiceImpl) Submit(ctx context.Context, input SubmitDebriefInput) (*models.MissionDebrief, error) {
+	const cacheKey = "missions"
+
+	mission, err := s.missionStore.ById(ctx, input.MissionId)
+	if err != nil {
+		return nil, err
+	}
+	if mission.CatId == nil {
+		return nil, errors.ErrConflict{Msg: "Cannot submit debrief: no cat is assigned"}
+	}
+
+	outcomes, err := debriefOutcomes(mission, input.Outcomes)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := s.store.Save(ctx, &models.MissionDebrief{
+		MissionID:      mission.ID,
+		CatID:          mission.CatId,
+		CatRating:      input.CatRating,
+		LessonsLearned: input.LessonsLearned,
+		Author:         input.Author,
+		CreatedAt:      time.Now().UTC(),
+		Outcomes:       outcomes,
+	}, s.requireDebrief)
+	if err != nil {
+		return nil, err
+	}
+	if completed {
+		_ = s.cache.Del(ctx, cacheKey)
+	}
+
+	return s.store.ByMission(ctx, mission.ID)
+}
+
+func (s *DebriefServiceImpl) ByMission(ctx context.Context, missionId uuid.UUID) (*models.MissionDebrief, error) {
+	_, err := s.missionStore.ById(ctx, missionId)
+	if err != nil {
+		return nil, err
+	}
+
+	debrief, err := s.store.ByMission(ctx, missionId)
+	if err != nil {
+		return nil, err
+	}
+	return debrief, nil
+}
+
+func (s *DebriefServiceImpl) Report(ctx context.Context, missionId uuid.UUID) (*DebriefReport, error) {
+	debrief, err := s.ByMission(ctx, missionId)
+	if err != nil {
+		return nil, err
+	}
+	mission, err := s.missionStore.ById(ctx, missionId)
+	if err != nil {
+		return nil, err
+	}
+
+	var cat *models.Cat
+	if debrief.CatID != nil {
+		cat, err = s.catStore.ById(ctx, *debrief.CatID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	outcomes := make(map[uuid.UUID]*models.DebriefOutcome, len(debrief.Outcomes))
+	for _, o := range debrief.Outcomes {
+		outcomes[o.TargetID] = o
+	}
+
+	targets := make([]*DebriefReportTarget, len(mission.Targets))
+	for i, t := range mission.Targets {
+		revisions, err := s.targetStore.NoteRevisions(ctx, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		targets[i] = &DebriefReportTarget{
+			Target:  t,
+			Outcome: outcomes[t.ID],
+			Notes:   revisions,
+		}
+	}
+
+	transitions, err := s.missionStore.Transitions(ctx, missionId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DebriefReport{
+		Mission:     mission,
+		Cat:         cat,
+		Debrief:     debrief,
+		Targets:     targets,
+		Transitions: transitions,
+		GeneratedAt: time.Now().UTC(),
+	}, nil
+}
+
+func debriefOutcomes(mission *models.Mission, inputs []DebriefOutcomeInput) ([]*models.DebriefOutcome, error) {
+	byTarget := make(map[uuid.UUID]DebriefOutcomeInput, len(inputs))
+	for _, o := range inputs {
+		if _, err := missionTarget(mission, o.TargetId); err != nil {
+			return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Target %s does not belong to mission", o.TargetId)}
+		}
+		if _, ok := byTarget[o.TargetId]; ok {
+			return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Duplicate outcome for target %s", o.TargetId)}
+		}
+		byTarget[o.TargetId] = o
+	}
+
+	outcomes := make([]*models.DebriefOutcome, 0, len(mission.Targets))
+	for _, t := range mission.Targets {
+		o, ok := byTarget[t.ID]
+		if !ok {
+			return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Missing outcome for target %s", t.ID)}
+		}
+		outcomes = append(outcomes, &models.DebriefOutcome{
+			MissionID: mission.ID,
+			TargetID:  t.ID,
+			Outcome:   o.Outcome,
+			Notes:     o.Notes,
+		})
+	}
+	return outcomes, nil
+}
+
+func checkDebrief(ctx context.Context, store storage.DebriefStorage, missionId uuid.UUID) error {
+	exists, err := store.Exists(ctx, missionId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return errors.ErrConflict{Msg: "Cannot complete mission: a debrief must be submitted first"}
+	}
+	return nil
+}
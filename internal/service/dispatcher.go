@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"log"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/errors"
+	"sca/pkg/notify"
+)
+
+const (
+	EventMissionAutoAssigned = "mission.auto_assigned"
+
+	dispatcherLock      = "sca.dispatcher"
+	defaultDispatchSize = 20
+)
+
+type Dispatcher struct {
+	store     storage.MissionStorage
+	locks     storage.LockStorage
+	missions  MissionService
+	recommend RecommendService
+	notifier  notify.Notifier
+}
+
+func NewDispatcher(store storage.MissionStorage, locks storage.LockStorage, missions MissionService, recommend RecommendService, notifier notify.Notifier) *Dispatcher {
+	return &Dispatcher{
+		store:     store,
+		locks:     locks,
+		missions:  missions,
+		recommend: recommend,
+		notifier:  notifier,
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration, batchSize int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.Dispatch(ctx, batchSize); err != nil {
+			log.Printf("Auto-dispatch failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) Dispatch(ctx context.Context, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = defaultDispatchSize
+	}
+
+	unlock, acquired, err := d.locks.TryLock(ctx, dispatcherLock)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+	defer unlock()
+
+	missions, err := d.store.Unassigned(ctx, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for _, mission := range missions {
+		ok, err := d.dispatch(ctx, mission)
+		if err != nil {
+			log.Printf("Failed to auto-dispatch mission %s: %v", mission.ID, err)
+			continue
+		}
+		if ok {
+			assigned++
+		}
+	}
+	return assigned, nil
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, mission *models.Mission) (bool, error) {
+	recommendations, err := d.recommend.RecommendCats(ctx, RecommendCatsInput{MissionId: mission.ID})
+	if err != nil {
+		return false, err
+	}
+
+	for _, rec := range recommendations {
+		if len(rec.MissingSkills) > 0 {
+			break
+		}
+
+		reason := fmt.Sprintf("Auto-dispatched: best available cat with score %.3f", rec.Score)
+		err := d.missions.AssignCat(ctx, AssignCatInput{
+			MissionId: mission.ID,
+			CatId:     rec.Cat.ID,
+			Reason:    &reason,
+		})
+		if err != nil {
+			var conflict errors.ErrConflict
+			if stderrors.As(err, &conflict) {
+				if assigned, _ := d.missions.ById(ctx, mission.ID); assigned != nil && assigned.CatId != nil {
+					return false, nil
+				}
+				continue
+			}
+			return false, err
+		}
+
+		err = d.notifier.Notify(ctx, notify.Event{
+			Type:       EventMissionAutoAssigned,
+			Message:    fmt.Sprintf("Mission %s was auto-assigned to cat %s", mission.ID, rec.Cat.ID),
+			Data:       rec,
+			OccurredAt: time.Now().UTC(),
+		})
+		if err != nil {
+			log.Printf("Failed to notify about auto-assigned mission %s: %v", mission.ID, err)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"sca/internal/models"
+)
+
+type ExportInput struct {
+	Entity TransferEntity
+	Format TransferFormat
+}
+
+func (s *TransferServiceImpl) Export(ctx context.Context, input ExportInput) (func(w io.Writer) error, error) {
+	records, err := s.exportRecords(ctx, input.Entity, input.Format != FormatCSV)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(w io.Writer) error {
+		switch input.Format {
+		case FormatCSV:
+			cw := csv.NewWriter(w)
+			_ = cw.Write(transferColumns(input.Entity))
+			for _, r := range records {
+				_ = cw.Write(r.csvRow())
+			}
+			cw.Flush()
+			return cw.Error()
+		case FormatNDJSON:
+			enc := json.NewEncoder(w)
+			for _, r := range records {
+				if err := enc.Encode(r); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return json.NewEncoder(w).Encode(records)
+		}
+	}, nil
+}
+
+func (s *TransferServiceImpl) exportRecords(ctx context.Context, entity TransferEntity, nested bool) ([]transferRecord, error) {
+	records := []transferRecord{}
+
+	switch entity {
+	case TransferCats:
+		cats, err := s.cats.All(ctx, models.CatFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cats {
+			records = append(records, newCatRecord(c))
+		}
+	case TransferMissions:
+		missions, err := s.missions.All(ctx, models.MissionFilter{})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range missions {
+			records = append(records, newMissionRecord(m, nested))
+		}
+	case TransferTargets:
+		targets, err := s.targets.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range targets {
+			records = append(records, newTargetRecord(t))
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,391 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"sca/internal/models"
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+)
+
+const (
+	importReason     = "Imported"
+	maxNDJSONLineLen = 1 << 20
+)
+
+type ImportInput struct {
+	Entity TransferEntity
+	Format TransferFormat
+	Reader io.Reader
+}
+
+type ImportRowError struct {
+	Row   int        `json:"row"`
+	ID    *uuid.UUID `json:"id,omitempty"`
+	Error string     `json:"error"`
+}
+
+type ImportResult struct {
+	Entity  TransferEntity    `json:"entity"`
+	Format  TransferFormat    `json:"format"`
+	Created int               `json:"created"`
+	Skipped int               `json:"skipped"`
+	Failed  int               `json:"failed"`
+	Errors  []*ImportRowError `json:"errors"`
+}
+
+func (r *ImportResult) fail(row int, id uuid.UUID, err error) {
+	rowErr := &ImportRowError{Row: row, Error: err.Error()}
+	if id != uuid.Nil {
+		rowErr.ID = &id
+	}
+	r.Errors = append(r.Errors, rowErr)
+	r.Failed++
+}
+
+type rowError struct {
+	err error
+}
+
+func (e rowError) Error() string {
+	return e.err.Error()
+}
+
+type importRecord interface {
+	recordId() uuid.UUID
+}
+
+func (r *catRecord) recordId() uuid.UUID     { return r.ID }
+func (r *missionRecord) recordId() uuid.UUID { return r.ID }
+func (r *targetRecord) recordId() uuid.UUID  { return r.ID }
+
+func (s *TransferServiceImpl) Import(ctx context.Context, input ImportInput) (*ImportResult, error) {
+	next, err := importDecoder(input)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		Entity: input.Entity,
+		Format: input.Format,
+		Errors: []*ImportRowError{},
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, row, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var invalid rowError
+			if stderrors.As(err, &invalid) {
+				result.fail(row, uuid.Nil, invalid.err)
+				continue
+			}
+			return nil, err
+		}
+
+		created, err := s.importRecord(ctx, record)
+		if err != nil {
+			result.fail(row, record.recordId(), err)
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Skipped++
+		}
+	}
+
+	return result, nil
+}
+
+func (s *TransferServiceImpl) importRecord(ctx context.Context, record importRecord) (bool, error) {
+	if err := s.validator.Validate(record); err != nil {
+		return false, err
+	}
+	return s.createRecord(ctx, record)
+}
+
+func (s *TransferServiceImpl) createRecord(ctx context.Context, record importRecord) (bool, error) {
+	exists, err := s.exists(ctx, record)
+	if err != nil || exists {
+		return false, err
+	}
+
+	switch r := record.(type) {
+	case *catRecord:
+		return true, s.importCat(ctx, r)
+	case *missionRecord:
+		return true, s.importMission(ctx, r)
+	case *targetRecord:
+		return true, s.importTarget(ctx, r)
+	default:
+		return false, fmt.Errorf("unsupported import record %T", record)
+	}
+}
+
+func (s *TransferServiceImpl) exists(ctx context.Context, record importRecord) (bool, error) {
+	var err error
+	switch record.(type) {
+	case *catRecord:
+		_, err = s.cats.ById(ctx, record.recordId())
+	case *missionRecord:
+		_, err = s.missions.ById(ctx, record.recordId())
+	case *targetRecord:
+		_, err = s.targets.ById(ctx, record.recordId())
+	}
+
+	var notFound errors.ErrNotFound
+	if stderrors.As(err, &notFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *TransferServiceImpl) importCat(ctx context.Context, r *catRecord) error {
+	cat, err := s.cats.Create(ctx, CreateCatInput{
+		ID:                r.ID,
+		Name:              r.Name,
+		YearsOfExperience: r.YearsOfExperience,
+		Breed:             r.Breed,
+		Salary:            r.Salary,
+		Skills:            r.Skills,
+	})
+	if err != nil {
+		return err
+	}
+
+	if status := models.CatStatus(r.Status); status != "" && status != cat.Status {
+		if _, err := s.cats.UpdateStatus(ctx, cat.ID, status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *TransferServiceImpl) importTarget(ctx context.Context, r *targetRecord) error {
+	target, err := s.targets.Create(ctx, targetInput(r))
+	if err != nil {
+		return err
+	}
+
+	if r.MissionID != nil {
+		err = s.missions.AddTarget(ctx, AddTargetInput{
+			MissionId: *r.MissionID,
+			TargetId:  target.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if r.Complete {
+		return s.targets.MarkComplete(ctx, target.ID)
+	}
+	return nil
+}
+
+func (s *TransferServiceImpl) importMission(ctx context.Context, r *missionRecord) error {
+	targets := make([]CreateTargetInput, len(r.Targets))
+	for i, t := range r.Targets {
+		targets[i] = targetInput(t)
+	}
+
+	var catId uuid.UUID
+	if r.CatId != nil {
+		catId = *r.CatId
+	}
+
+	mission, err := s.missions.Create(ctx, CreateMissionInput{
+		ID:           r.ID,
+		CatId:        catId,
+		Priority:     r.Priority,
+		DueAt:        r.DueAt,
+		Budget:       r.Budget,
+		Targets:      targets,
+		IgnoreSkills: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, t := range r.Targets {
+		if t.Complete {
+			if err := s.targets.MarkComplete(ctx, t.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.restoreStatus(ctx, mission.ID, models.MissionStatus(r.Status))
+}
+
+func (s *TransferServiceImpl) restoreStatus(ctx context.Context, id uuid.UUID, status models.MissionStatus) error {
+	mission, err := s.missions.ById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if status == "" || mission.Status == status {
+		return nil
+	}
+
+	switch status {
+	case models.MissionInProgress:
+		return s.missions.Start(ctx, id)
+	case models.MissionCompleted:
+		return s.missions.MarkComplete(ctx, MarkCompleteMissionInput{ID: id, Force: true, Reason: importReason})
+	case models.MissionAborted:
+		return s.missions.Abort(ctx, TransitionMissionInput{ID: id, Reason: importReason})
+	case models.MissionFailed:
+		if mission.Status != models.MissionInProgress {
+			if err := s.missions.Start(ctx, id); err != nil {
+				return err
+			}
+		}
+		return s.missions.Fail(ctx, TransitionMissionInput{ID: id, Reason: importReason})
+	default:
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot restore mission status %s: mission was imported as %s", status, mission.Status)}
+	}
+}
+
+func targetInput(r *targetRecord) CreateTargetInput {
+	return CreateTargetInput{
+		ID:             r.ID,
+		Name:           r.Name,
+		Country:        r.Country,
+		Latitude:       r.Latitude,
+		Longitude:      r.Longitude,
+		LastSeenAt:     r.LastSeenAt,
+		Notes:          r.Notes,
+		RequiredSkills: r.RequiredSkills,
+	}
+}
+
+func newImportRecord(entity TransferEntity) importRecord {
+	switch entity {
+	case TransferCats:
+		return &catRecord{}
+	case TransferMissions:
+		return &missionRecord{}
+	default:
+		return &targetRecord{}
+	}
+}
+
+func importDecoder(input ImportInput) (func() (importRecord, int, error), error) {
+	switch input.Format {
+	case FormatCSV:
+		return csvDecoder(input.Entity, input.Reader)
+	case FormatJSON:
+		return jsonDecoder(input.Entity, input.Reader)
+	case FormatNDJSON:
+		return ndjsonDecoder(input.Entity, input.Reader), nil
+	default:
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Unsupported import format: %s", input.Format)}
+	}
+}
+
+func csvDecoder(entity TransferEntity, r io.Reader) (func() (importRecord, int, error), error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid CSV header: %v", err)}
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	if _, ok := columns["id"]; !ok {
+		return nil, errors.ErrBadRequest{Msg: "Invalid CSV header: missing id column"}
+	}
+
+	return func() (importRecord, int, error) {
+		values, err := reader.Read()
+		if err == io.EOF {
+			return nil, 0, io.EOF
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if stderrors.As(err, &parseErr) {
+				return nil, parseErr.StartLine, rowError{err: errors.ErrBadRequest{Msg: parseErr.Error()}}
+			}
+			return nil, 0, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		row := &csvRow{columns: columns, values: values}
+		var record importRecord
+		switch entity {
+		case TransferCats:
+			record, err = row.catRecord()
+		case TransferMissions:
+			record, err = row.missionRecord()
+		default:
+			record, err = row.targetRecord()
+		}
+		if err != nil {
+			return nil, line, rowError{err: err}
+		}
+		return record, line, nil
+	}, nil
+}
+
+func jsonDecoder(entity TransferEntity, r io.Reader) (func() (importRecord, int, error), error) {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, errors.ErrBadRequest{Msg: "Invalid JSON import: expected an array of records"}
+	}
+
+	row, broken := 0, false
+	return func() (importRecord, int, error) {
+		if broken || !dec.More() {
+			return nil, 0, io.EOF
+		}
+		row++
+		record := newImportRecord(entity)
+		if err := dec.Decode(record); err != nil {
+			var syntaxErr *json.SyntaxError
+			broken = stderrors.As(err, &syntaxErr) || stderrors.Is(err, io.ErrUnexpectedEOF)
+			return nil, row, rowError{err: errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid JSON: %v", err)}}
+		}
+		return record, row, nil
+	}, nil
+}
+
+func ndjsonDecoder(entity TransferEntity, r io.Reader) func() (importRecord, int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineLen)
+
+	line := 0
+	return func() (importRecord, int, error) {
+		for scanner.Scan() {
+			line++
+			data := strings.TrimSpace(scanner.Text())
+			if data == "" {
+				continue
+			}
+			record := newImportRecord(entity)
+			if err := json.Unmarshal([]byte(data), record); err != nil {
+				return nil, line, rowError{err: errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid JSON: %v", err)}}
+			}
+			return record, line, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, line, errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid NDJSON at line %d: %v", line+1, err)}
+		}
+		return nil, line, io.EOF
+	}
+}
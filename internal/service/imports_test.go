@@ -0,0 +1,47 @@
+package service
+
+import (
+	stderrors "errors"
+	"io"
+	"strings"
+	"testing"
+
+	"sca/pkg/errors"
+)
+
+func TestCSVDecoderMalformedRow(t *testing.T) {
+	input := strings.Join([]string{
+		"id,name,years_of_experience,breed,status,salary,salary_currency,skills",
+		`0b6f8a52-3c1e-4f43-9d3a-2f6f4c1a7e"01,Tommy,3,Siamese,active,1000.00,USD,`,
+		"0b6f8a52-3c1e-4f43-9d3a-2f6f4c1a7e02,Tom,3,Siamese,active,1000.00,USD,",
+		"0b6f8a52-3c1e-4f43-9d3a-2f6f4c1a7e03,Felix,5,Bengal,active,2000.00,USD,",
+	}, "\n")
+
+	next, err := csvDecoder(TransferCats, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("csvDecoder() error = %v", err)
+	}
+
+	_, line, err := next()
+	var rowErr rowError
+	if !stderrors.As(err, &rowErr) || line != 2 {
+		t.Fatalf("row 2: got line %d, error %v, want a row error on line 2", line, err)
+	}
+	if _, ok := rowErr.err.(errors.ErrBadRequest); !ok {
+		t.Errorf("row 2: got error %T, want errors.ErrBadRequest", rowErr.err)
+	}
+
+	record, line, err := next()
+	if err != nil || line != 3 || record.(*catRecord).Name != "Tom" {
+		t.Fatalf("row 3: got line %d, error %v", line, err)
+	}
+
+	record, line, err = next()
+	if err != nil || line != 4 || record.(*catRecord).Name != "Felix" {
+		t.Fatalf("row 4: got line %d, error %v", line, err)
+	}
+
+	if _, _, err = next(); err != io.EOF {
+		t.Errorf("got error %v, want io.EOF", err)
+	}
+}
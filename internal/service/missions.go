@@ -2,24 +2,70 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"sca/internal/models"
 	"sca/internal/storage"
+	"sca/pkg/blob"
 	"sca/pkg/cache"
 	"sca/pkg/errors"
+	"sca/pkg/geojson"
+	"sca/pkg/money"
 
 	"github.com/google/uuid"
 )
 
 type CreateMissionInput struct {
-	CatId   uuid.UUID
-	Targets []CreateTargetInput
+	ID           uuid.UUID
+	CatId        uuid.UUID
+	Priority     int
+	DueAt        *time.Time
+	Budget       money.Money
+	Targets      []CreateTargetInput
+	IgnoreSkills bool
+}
+
+type CreateFromTemplateInput struct {
+	TemplateId   uuid.UUID
+	CatId        uuid.UUID
+	DueAt        *time.Time
+	IgnoreSkills bool
+}
+
+type CloneMissionInput struct {
+	ID           uuid.UUID
+	CatId        uuid.UUID
+	DueAt        *time.Time
+	IgnoreSkills bool
+}
+
+type UpdateMissionInput struct {
+	ID       uuid.UUID
+	Priority *int
+	DueAt    *time.Time
+	Budget   *money.Money
 }
 
 type AssignCatInput struct {
+	MissionId    uuid.UUID
+	CatId        uuid.UUID
+	Reason       *string
+	IgnoreSkills bool
+}
+
+type UnassignCatInput struct {
 	MissionId uuid.UUID
-	CatId     uuid.UUID
+	Reason    string
+}
+
+type ReassignCatInput struct {
+	MissionId    uuid.UUID
+	CatId        uuid.UUID
+	Reason       string
+	IgnoreSkills bool
 }
 
 type AddTargetInput struct {
@@ -27,65 +73,152 @@ type AddTargetInput struct {
 	TargetId  uuid.UUID
 }
 
+type MarkCompleteMissionInput struct {
+	ID     uuid.UUID
+	Force  bool
+	Reason string
+}
+
+type TransitionMissionInput struct {
+	ID     uuid.UUID
+	Reason string
+}
+
+type DetachTargetInput struct {
+	MissionId uuid.UUID
+	TargetId  uuid.UUID
+}
+
+type MoveTargetInput struct {
+	MissionId   uuid.UUID
+	TargetId    uuid.UUID
+	ToMissionId uuid.UUID
+}
+
+type ReorderTargetsInput struct {
+	MissionId uuid.UUID
+	TargetIds []uuid.UUID
+}
+
 type MissionService interface {
 	Create(ctx context.Context, input CreateMissionInput) (*models.Mission, error)
 	ById(ctx context.Context, id uuid.UUID) (*models.Mission, error)
-	All(ctx context.Context) ([]*models.Mission, error)
+	All(ctx context.Context, filter models.MissionFilter) ([]*models.Mission, error)
+	Update(ctx context.Context, input UpdateMissionInput) (*models.Mission, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	MarkComplete(ctx context.Context, id uuid.UUID) error
+	MarkComplete(ctx context.Context, input MarkCompleteMissionInput) error
+	Start(ctx context.Context, id uuid.UUID) error
+	Abort(ctx context.Context, input TransitionMissionInput) error
+	Fail(ctx context.Context, input TransitionMissionInput) error
+	Transitions(ctx context.Context, id uuid.UUID) ([]*models.MissionTransition, error)
 	AssignCat(ctx context.Context, input AssignCatInput) error
+	UnassignCat(ctx context.Context, input UnassignCatInput) error
+	ReassignCat(ctx context.Context, input ReassignCatInput) error
+	Assignments(ctx context.Context, id uuid.UUID) ([]*models.MissionAssignment, error)
 	AddTarget(ctx context.Context, input AddTargetInput) error
+	DetachTarget(ctx context.Context, input DetachTargetInput) error
+	MoveTarget(ctx context.Context, input MoveTargetInput) error
+	ReorderTargets(ctx context.Context, input ReorderTargetsInput) error
+	GeoJSON(ctx context.Context, id uuid.UUID) (*geojson.FeatureCollection, error)
+	CreateFromTemplate(ctx context.Context, input CreateFromTemplateInput) (*models.Mission, error)
+	Clone(ctx context.Context, input CloneMissionInput) (*models.Mission, error)
 }
 
 type MissionServiceImpl struct {
-	store       storage.MissionStorage
-	catStore    storage.CatStorage
-	targetStore storage.TargetStorage
-	cache       cache.Cache
+	store          storage.MissionStorage
+	catStore       storage.CatStorage
+	targetStore    storage.TargetStorage
+	templateStore  storage.TemplateStorage
+	debriefStore   storage.DebriefStorage
+	blobs          blob.Store
+	cache          cache.Cache
+	requireDebrief bool
 }
 
-func NewMissionService(store storage.MissionStorage, catStore storage.CatStorage, targetStore storage.TargetStorage, cache cache.Cache) *MissionServiceImpl {
+func NewMissionService(store storage.MissionStorage, catStore storage.CatStorage, targetStore storage.TargetStorage, templateStore storage.TemplateStorage, debriefStore storage.DebriefStorage, blobs blob.Store, cache cache.Cache, requireDebrief bool) *MissionServiceImpl {
 	return &MissionServiceImpl{
-		store:       store,
-		catStore:    catStore,
-		targetStore: targetStore,
-		cache:       cache,
+		store:          store,
+		catStore:       catStore,
+		targetStore:    targetStore,
+		templateStore:  templateStore,
+		debriefStore:   debriefStore,
+		blobs:          blobs,
+		cache:          cache,
+		requireDebrief: requireDebrief,
 	}
 }
 
 func (s *MissionServiceImpl) Create(ctx context.Context, input CreateMissionInput) (*models.Mission, error) {
 	const cacheKey = "missions"
 
+	if len(input.Targets) < 1 || len(input.Targets) > models.MaxMissionTargets {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Cannot create mission: a mission must have between 1 and %d targets", models.MaxMissionTargets)}
+	}
+
 	var cat *models.Cat
 	var err error
 	var catIdPtr *uuid.UUID
 	if input.CatId != uuid.Nil {
-		cat, err = s.catStore.ById(ctx, input.CatId)
+		cat, err = s.availableCat(ctx, input.CatId, input.DueAt, "Cannot create mission")
 		if err != nil {
 			return nil, err
 		}
 		catIdPtr = &cat.ID
 	}
 
+	status := models.MissionDraft
+	if catIdPtr != nil {
+		status = models.MissionAssigned
+	}
+
+	priority := input.Priority
+	if priority == 0 {
+		priority = models.DefaultMissionPriority
+	}
+
+	budget := input.Budget
+	if budget.Currency == "" {
+		budget.Currency = money.DefaultCurrency
+	}
+
 	mission := &models.Mission{
-		ID:       uuid.New(),
+		ID:       newId(input.ID),
+		Status:   status,
+		Priority: priority,
+		DueAt:    input.DueAt,
+		Budget:   budget,
 		CatId:    catIdPtr,
-		Complete: false,
 	}
 
 	targets := make([]*models.Target, len(input.Targets))
 	for i, t := range input.Targets {
+		countryCode, err := normalizeCountry(t.Country)
+		if err != nil {
+			return nil, err
+		}
 		targets[i] = &models.Target{
-			ID:        uuid.New(),
-			Name:      t.Name,
-			Country:   t.Country,
-			Notes:     t.Notes,
-			Complete:  false,
-			MissionID: &mission.ID,
+			ID:             newId(t.ID),
+			Name:           t.Name,
+			Country:        countryCode,
+			Latitude:       t.Latitude,
+			Longitude:      t.Longitude,
+			LastSeenAt:     t.LastSeenAt,
+			Notes:          t.Notes,
+			Complete:       false,
+			MissionID:      &mission.ID,
+			Position:       i,
+			RequiredSkills: uniqueSkills(t.RequiredSkills),
 		}
 	}
 	mission.Targets = targets
 
+	if cat != nil {
+		_, err = checkSkills(cat, targets, input.IgnoreSkills, "Cannot create mission")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = s.store.Create(ctx, mission, targets)
 	if err != nil {
 		return nil, err
@@ -96,6 +229,64 @@ func (s *MissionServiceImpl) Create(ctx context.Context, input CreateMissionInpu
 	return mission, nil
 }
 
+func (s *MissionServiceImpl) CreateFromTemplate(ctx context.Context, input CreateFromTemplateInput) (*models.Mission, error) {
+	template, err := s.templateStore.ById(ctx, input.TemplateId)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]CreateTargetInput, len(template.Targets))
+	for i, t := range template.Targets {
+		targets[i] = CreateTargetInput{
+			Name:           t.Name,
+			Country:        t.Country,
+			Notes:          t.Notes,
+			RequiredSkills: t.RequiredSkills,
+		}
+	}
+
+	return s.Create(ctx, CreateMissionInput{
+		CatId:        input.CatId,
+		Priority:     template.Priority,
+		DueAt:        input.DueAt,
+		Budget:       template.Budget,
+		Targets:      targets,
+		IgnoreSkills: input.IgnoreSkills,
+	})
+}
+
+func (s *MissionServiceImpl) Clone(ctx context.Context, input CloneMissionInput) (*models.Mission, error) {
+	mission, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(mission.Targets) == 0 {
+		return nil, errors.ErrConflict{Msg: "Cannot clone mission: mission has no targets"}
+	}
+
+	targets := make([]CreateTargetInput, len(mission.Targets))
+	for i, t := range mission.Targets {
+		targets[i] = CreateTargetInput{
+			Name:           t.Name,
+			Country:        t.Country,
+			Latitude:       t.Latitude,
+			Longitude:      t.Longitude,
+			LastSeenAt:     t.LastSeenAt,
+			Notes:          t.Notes,
+			RequiredSkills: t.RequiredSkills,
+		}
+	}
+
+	return s.Create(ctx, CreateMissionInput{
+		CatId:        input.CatId,
+		Priority:     mission.Priority,
+		DueAt:        input.DueAt,
+		Budget:       mission.Budget,
+		Targets:      targets,
+		IgnoreSkills: input.IgnoreSkills,
+	})
+}
+
 func (s *MissionServiceImpl) ById(ctx context.Context, id uuid.UUID) (*models.Mission, error) {
 	mission, err := s.store.ById(ctx, id)
 	if err != nil {
@@ -104,14 +295,18 @@ func (s *MissionServiceImpl) ById(ctx context.Context, id uuid.UUID) (*models.Mi
 	return mission, nil
 }
 
-func (s *MissionServiceImpl) All(ctx context.Context) ([]*models.Mission, error) {
+func (s *MissionServiceImpl) All(ctx context.Context, filter models.MissionFilter) ([]*models.Mission, error) {
 	const cacheKey = "missions"
 
+	if !filter.IsZero() {
+		return s.store.All(ctx, filter)
+	}
+
 	if items, _ := s.cache.Get(ctx, cacheKey); items != nil {
 		return items.([]*models.Mission), nil
 	}
 
-	missions, err := s.store.All(ctx)
+	missions, err := s.store.All(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
@@ -121,6 +316,38 @@ func (s *MissionServiceImpl) All(ctx context.Context) ([]*models.Mission, error)
 	return missions, nil
 }
 
+func (s *MissionServiceImpl) Update(ctx context.Context, input UpdateMissionInput) (*models.Mission, error) {
+	const cacheKey = "missions"
+
+	mission, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mission.Status.IsFinal() {
+		return nil, errors.ErrConflict{Msg: fmt.Sprintf("Cannot update mission: mission is %s", mission.Status)}
+	}
+
+	if input.Priority != nil {
+		mission.Priority = *input.Priority
+	}
+	if input.DueAt != nil {
+		mission.DueAt = input.DueAt
+		mission.OverdueAt = nil
+	}
+	if input.Budget != nil {
+		mission.Budget = *input.Budget
+	}
+
+	err = s.store.Update(ctx, mission)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return mission, nil
+}
+
 func (s *MissionServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	const cacheKey = "missions"
 
@@ -132,28 +359,108 @@ func (s *MissionServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 		return errors.ErrConflict{Msg: "Cannot delete mission: cat is assigned"}
 	}
 
-	err = s.store.Delete(ctx, id)
+	blobKeys, err := s.store.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
+	deleteBlobs(ctx, s.blobs, blobKeys)
 
 	_ = s.cache.Del(ctx, cacheKey)
 
 	return nil
 }
 
-func (s *MissionServiceImpl) MarkComplete(ctx context.Context, id uuid.UUID) error {
-	const cacheKey = "missions"
+func (s *MissionServiceImpl) MarkComplete(ctx context.Context, input MarkCompleteMissionInput) error {
+	mission, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return err
+	}
 
-	_, err := s.ById(ctx, id)
+	var reason *string
+	if input.Force {
+		reason = &input.Reason
+	} else {
+		for _, t := range mission.Targets {
+			if !t.Complete {
+				return errors.ErrConflict{Msg: "Cannot complete mission: not all targets are completed"}
+			}
+		}
+	}
+	if s.requireDebrief {
+		if err := checkDebrief(ctx, s.debriefStore, mission.ID); err != nil {
+			return err
+		}
+	}
+
+	return s.transition(ctx, mission, models.MissionCompleted, reason)
+}
+
+func (s *MissionServiceImpl) Start(ctx context.Context, id uuid.UUID) error {
+	mission, err := s.ById(ctx, id)
 	if err != nil {
 		return err
 	}
+	if mission.CatId == nil {
+		return errors.ErrConflict{Msg: "Cannot start mission: no cat is assigned"}
+	}
+	if len(mission.Targets) == 0 {
+		return errors.ErrConflict{Msg: "Cannot start mission: mission has no targets"}
+	}
+
+	return s.transition(ctx, mission, models.MissionInProgress, nil)
+}
+
+func (s *MissionServiceImpl) Abort(ctx context.Context, input TransitionMissionInput) error {
+	mission, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.transition(ctx, mission, models.MissionAborted, &input.Reason)
+}
+
+func (s *MissionServiceImpl) Fail(ctx context.Context, input TransitionMissionInput) error {
+	mission, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.transition(ctx, mission, models.MissionFailed, &input.Reason)
+}
+
+func (s *MissionServiceImpl) Transitions(ctx context.Context, id uuid.UUID) ([]*models.MissionTransition, error) {
+	_, err := s.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	err = s.store.MarkComplete(ctx, id)
+	transitions, err := s.store.Transitions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+func (s *MissionServiceImpl) transition(ctx context.Context, mission *models.Mission, to models.MissionStatus, reason *string) error {
+	const cacheKey = "missions"
+
+	if !mission.Status.CanTransitionTo(to) {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot move mission from %s to %s", mission.Status, to)}
+	}
+
+	from := mission.Status
+	err := s.store.Transition(ctx, &models.MissionTransition{
+		ID:         uuid.New(),
+		MissionID:  mission.ID,
+		FromStatus: &from,
+		ToStatus:   to,
+		Reason:     reason,
+		CreatedAt:  time.Now().UTC(),
+	})
 	if err != nil {
 		return err
 	}
+	mission.Status = to
 
 	_ = s.cache.Del(ctx, cacheKey)
 
@@ -161,25 +468,194 @@ func (s *MissionServiceImpl) MarkComplete(ctx context.Context, id uuid.UUID) err
 }
 
 func (s *MissionServiceImpl) AssignCat(ctx context.Context, input AssignCatInput) error {
-	const cacheKey = "missions"
+	mission, err := s.ById(ctx, input.MissionId)
+	if err != nil {
+		return err
+	}
+	if mission.Status.IsFinal() {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot assign cat: mission is %s", mission.Status)}
+	}
+	if mission.CatId != nil {
+		return errors.ErrConflict{Msg: "Cannot assign cat: mission already has a cat, reassign it instead"}
+	}
 
+	cat, err := s.availableCat(ctx, input.CatId, mission.DueAt, "Cannot assign cat")
+	if err != nil {
+		return err
+	}
+	warning, err := checkSkills(cat, mission.Targets, input.IgnoreSkills, "Cannot assign cat")
+	if err != nil {
+		return err
+	}
+	reason := withWarning(input.Reason, warning)
+
+	var to *models.MissionStatus
+	if mission.Status == models.MissionDraft {
+		assigned := models.MissionAssigned
+		to = &assigned
+	}
+
+	return s.changeCat(ctx, mission, &input.CatId, to, reason)
+}
+
+func (s *MissionServiceImpl) UnassignCat(ctx context.Context, input UnassignCatInput) error {
 	mission, err := s.ById(ctx, input.MissionId)
 	if err != nil {
 		return err
 	}
-	if mission.Complete {
-		return errors.ErrConflict{Msg: "Cannot assign cat: mission is completed"}
+	if mission.CatId == nil {
+		return errors.ErrConflict{Msg: "Cannot unassign cat: mission has no cat"}
+	}
+	if mission.Status != models.MissionAssigned {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot unassign cat: mission is %s", mission.Status)}
 	}
 
-	_, err = s.catStore.ById(ctx, input.CatId)
+	draft := models.MissionDraft
+	return s.changeCat(ctx, mission, nil, &draft, &input.Reason)
+}
+
+func (s *MissionServiceImpl) ReassignCat(ctx context.Context, input ReassignCatInput) error {
+	mission, err := s.ById(ctx, input.MissionId)
 	if err != nil {
 		return err
 	}
+	if mission.Status.IsFinal() {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot reassign cat: mission is %s", mission.Status)}
+	}
+	if mission.CatId == nil {
+		return errors.ErrConflict{Msg: "Cannot reassign cat: mission has no cat, assign one instead"}
+	}
+	if *mission.CatId == input.CatId {
+		return errors.ErrConflict{Msg: "Cannot reassign cat: cat is already assigned to this mission"}
+	}
 
-	err = s.store.AssignCat(ctx, input.MissionId, input.CatId)
+	cat, err := s.availableCat(ctx, input.CatId, mission.DueAt, "Cannot reassign cat")
 	if err != nil {
 		return err
 	}
+	warning, err := checkSkills(cat, mission.Targets, input.IgnoreSkills, "Cannot reassign cat")
+	if err != nil {
+		return err
+	}
+
+	return s.changeCat(ctx, mission, &input.CatId, nil, withWarning(&input.Reason, warning))
+}
+
+func (s *MissionServiceImpl) Assignments(ctx context.Context, id uuid.UUID) ([]*models.MissionAssignment, error) {
+	_, err := s.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.store.Assignments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (s *MissionServiceImpl) availableCat(ctx context.Context, catId uuid.UUID, dueAt *time.Time, prefix string) (*models.Cat, error) {
+	cat, err := s.catStore.ById(ctx, catId)
+	if err != nil {
+		return nil, err
+	}
+
+	from := time.Now().UTC()
+	to := from
+	if dueAt != nil && dueAt.After(from) {
+		to = *dueAt
+	}
+	if !cat.AvailableBetween(from, to) {
+		if cat.Status != models.CatActive {
+			return nil, errors.ErrConflict{Msg: fmt.Sprintf("%s: cat is %s", prefix, cat.Status)}
+		}
+		return nil, errors.ErrConflict{Msg: fmt.Sprintf("%s: cat is on leave during the mission", prefix)}
+	}
+
+	return cat, nil
+}
+
+func checkSkills(cat *models.Cat, targets []*models.Target, ignore bool, prefix string) (*string, error) {
+	missing := cat.MissingSkills(requiredSkills(targets))
+	if len(missing) == 0 {
+		return nil, nil
+	}
+	if !ignore {
+		return nil, errors.ErrConflict{Msg: fmt.Sprintf("%s: cat lacks required skills: %s", prefix, strings.Join(missing, ", "))}
+	}
+
+	warning := fmt.Sprintf("cat lacks required skills: %s", strings.Join(missing, ", "))
+	return &warning, nil
+}
+
+func requiredSkills(targets []*models.Target) []string {
+	skills := []string{}
+	for _, t := range targets {
+		if t.Complete {
+			continue
+		}
+		for _, skill := range t.RequiredSkills {
+			if !slices.Contains(skills, skill) {
+				skills = append(skills, skill)
+			}
+		}
+	}
+	slices.Sort(skills)
+	return skills
+}
+
+func withWarning(reason *string, warning *string) *string {
+	const maxReasonLength = 255
+
+	if warning == nil {
+		return reason
+	}
+
+	combined := "Warning: " + *warning
+	if reason != nil {
+		combined = fmt.Sprintf("%s (warning: %s)", *reason, *warning)
+	}
+	if runes := []rune(combined); len(runes) > maxReasonLength {
+		combined = string(runes[:maxReasonLength-3]) + "..."
+	}
+	return &combined
+}
+
+func (s *MissionServiceImpl) changeCat(ctx context.Context, mission *models.Mission, catId *uuid.UUID, to *models.MissionStatus, reason *string) error {
+	const cacheKey = "missions"
+
+	now := time.Now().UTC()
+	var transition *models.MissionTransition
+	if to != nil {
+		if !mission.Status.CanTransitionTo(*to) {
+			return errors.ErrConflict{Msg: fmt.Sprintf("Cannot move mission from %s to %s", mission.Status, *to)}
+		}
+		from := mission.Status
+		transition = &models.MissionTransition{
+			ID:         uuid.New(),
+			MissionID:  mission.ID,
+			FromStatus: &from,
+			ToStatus:   *to,
+			Reason:     reason,
+			CreatedAt:  now,
+		}
+	}
+
+	err := s.store.ChangeCat(ctx, &models.MissionAssignment{
+		ID:        uuid.New(),
+		MissionID: mission.ID,
+		FromCatId: mission.CatId,
+		ToCatId:   catId,
+		Reason:    reason,
+		CreatedAt: now,
+	}, transition)
+	if err != nil {
+		return err
+	}
+	mission.CatId = catId
+	if to != nil {
+		mission.Status = *to
+	}
 
 	_ = s.cache.Del(ctx, cacheKey)
 
@@ -193,10 +669,10 @@ func (s *MissionServiceImpl) AddTarget(ctx context.Context, input AddTargetInput
 	if err != nil {
 		return err
 	}
-	if mission.Complete {
-		return errors.ErrConflict{Msg: "Cannot add target: mission is completed"}
+	if mission.Status.IsFinal() {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot add target: mission is %s", mission.Status)}
 	}
-	if len(mission.Targets) >= 3 {
+	if len(mission.Targets) >= models.MaxMissionTargets {
 		return errors.ErrConflict{Msg: "Cannot add target: mission already has 3 targets"}
 	}
 
@@ -204,8 +680,14 @@ func (s *MissionServiceImpl) AddTarget(ctx context.Context, input AddTargetInput
 	if err != nil {
 		return err
 	}
+	if target.Complete {
+		return errors.ErrConflict{Msg: "Cannot add target: target is completed"}
+	}
+	if target.MissionID != nil {
+		return errors.ErrConflict{Msg: "Cannot add target: target already belongs to a mission"}
+	}
 
-	err = s.store.AddTarget(ctx, input.MissionId, target)
+	err = s.store.AddTarget(ctx, input.MissionId, target.ID)
 	if err != nil {
 		return err
 	}
@@ -214,3 +696,157 @@ func (s *MissionServiceImpl) AddTarget(ctx context.Context, input AddTargetInput
 
 	return nil
 }
+
+func (s *MissionServiceImpl) DetachTarget(ctx context.Context, input DetachTargetInput) error {
+	const cacheKey = "missions"
+
+	mission, err := s.ById(ctx, input.MissionId)
+	if err != nil {
+		return err
+	}
+	if mission.Status.IsFinal() {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot detach target: mission is %s", mission.Status)}
+	}
+
+	target, err := missionTarget(mission, input.TargetId)
+	if err != nil {
+		return err
+	}
+	if target.Complete {
+		return errors.ErrConflict{Msg: "Cannot detach target: target is completed"}
+	}
+
+	if len(mission.Targets) == 1 {
+		return errors.ErrConflict{Msg: "Cannot detach target: it is the last target of the mission"}
+	}
+
+	_, err = s.store.DetachTarget(ctx, mission.ID, target.ID, s.requireDebrief)
+	if err != nil {
+		return err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return nil
+}
+
+func (s *MissionServiceImpl) MoveTarget(ctx context.Context, input MoveTargetInput) error {
+	const cacheKey = "missions"
+
+	if input.MissionId == input.ToMissionId {
+		return errors.ErrConflict{Msg: "Cannot move target: target already belongs to this mission"}
+	}
+
+	from, err := s.ById(ctx, input.MissionId)
+	if err != nil {
+		return err
+	}
+	if from.Status.IsFinal() {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot move target: source mission is %s", from.Status)}
+	}
+
+	target, err := missionTarget(from, input.TargetId)
+	if err != nil {
+		return err
+	}
+	if target.Complete {
+		return errors.ErrConflict{Msg: "Cannot move target: target is completed"}
+	}
+	if len(from.Targets) == 1 {
+		return errors.ErrConflict{Msg: "Cannot move target: it is the last target of the source mission"}
+	}
+
+	to, err := s.ById(ctx, input.ToMissionId)
+	if err != nil {
+		return err
+	}
+	if to.Status.IsFinal() {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot move target: destination mission is %s", to.Status)}
+	}
+	if len(to.Targets) >= models.MaxMissionTargets {
+		return errors.ErrConflict{Msg: "Cannot move target: destination mission already has 3 targets"}
+	}
+
+	_, err = s.store.MoveTarget(ctx, from.ID, to.ID, target.ID, s.requireDebrief)
+	if err != nil {
+		return err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return nil
+}
+
+func (s *MissionServiceImpl) ReorderTargets(ctx context.Context, input ReorderTargetsInput) error {
+	const cacheKey = "missions"
+
+	mission, err := s.ById(ctx, input.MissionId)
+	if err != nil {
+		return err
+	}
+	if mission.Status.IsFinal() {
+		return errors.ErrConflict{Msg: fmt.Sprintf("Cannot reorder targets: mission is %s", mission.Status)}
+	}
+	if len(input.TargetIds) != len(mission.Targets) {
+		return errors.ErrConflict{Msg: "Cannot reorder targets: order must list every mission target exactly once"}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(input.TargetIds))
+	for _, id := range input.TargetIds {
+		if seen[id] {
+			return errors.ErrConflict{Msg: "Cannot reorder targets: order must list every mission target exactly once"}
+		}
+		if _, err := missionTarget(mission, id); err != nil {
+			return err
+		}
+		seen[id] = true
+	}
+
+	err = s.store.ReorderTargets(ctx, mission.ID, input.TargetIds)
+	if err != nil {
+		return err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return nil
+}
+
+func (s *MissionServiceImpl) GeoJSON(ctx context.Context, id uuid.UUID) (*geojson.FeatureCollection, error) {
+	mission, err := s.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := geojson.NewFeatureCollection()
+	for _, t := range mission.Targets {
+		if t.Latitude == nil || t.Longitude == nil {
+			continue
+		}
+		collection.Features = append(collection.Features, geojson.NewPoint(t.ID.String(), *t.Longitude, *t.Latitude, map[string]any{
+			"name":         t.Name,
+			"country":      t.Country,
+			"complete":     t.Complete,
+			"position":     t.Position,
+			"last_seen_at": t.LastSeenAt,
+			"mission_id":   mission.ID,
+		}))
+	}
+	return collection, nil
+}
+
+func newId(id uuid.UUID) uuid.UUID {
+	if id == uuid.Nil {
+		return uuid.New()
+	}
+	return id
+}
+
+func missionTarget(mission *models.Mission, targetId uuid.UUID) (*models.Target, error) {
+	for _, t := range mission.Targets {
+		if t.ID == targetId {
+			return t, nil
+		}
+	}
+	return nil, errors.ErrNotFound{Msg: "Target not found in mission"}
+}
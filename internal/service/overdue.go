@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"sca/internal/storage"
+	"sca/pkg/cache"
+	"sca/pkg/notify"
+)
+
+const EventMissionOverdue = "mission.overdue"
+
+type OverdueChecker struct {
+	store    storage.MissionStorage
+	notifier notify.Notifier
+	cache    cache.Cache
+}
+
+func NewOverdueChecker(store storage.MissionStorage, notifier notify.Notifier, cache cache.Cache) *OverdueChecker {
+	return &OverdueChecker{
+		store:    store,
+		notifier: notifier,
+		cache:    cache,
+	}
+}
+
+func (c *OverdueChecker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Check(ctx); err != nil {
+			log.Printf("Overdue check failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *OverdueChecker) Check(ctx context.Context) error {
+	const cacheKey = "missions"
+
+	now := time.Now().UTC()
+	missions, err := c.store.Overdue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, mission := range missions {
+		flagged, err := c.store.MarkOverdue(ctx, mission.ID, now)
+		if err != nil {
+			return err
+		}
+		if !flagged {
+			continue
+		}
+
+		mission, err = c.store.ById(ctx, mission.ID)
+		if err != nil {
+			return err
+		}
+
+		err = c.notifier.Notify(ctx, notify.Event{
+			Type:       EventMissionOverdue,
+			Message:    fmt.Sprintf("Mission %s is overdue with open targets", mission.ID),
+			Data:       mission,
+			OccurredAt: now,
+		})
+		if err != nil {
+			log.Printf("Failed to notify about overdue mission %s: %v", mission.ID, err)
+		}
+	}
+
+	if len(missions) > 0 {
+		_ = c.cache.Del(ctx, cacheKey)
+	}
+
+	return nil
+}
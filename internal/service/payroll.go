@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type PayrollInput struct {
+	From time.Time
+	To   time.Time
+}
+
+type PayrollLine struct {
+	CatId   uuid.UUID   `json:"cat_id"`
+	CatName string      `json:"cat_name"`
+	Amount  money.Money `json:"amount"`
+}
+
+type PayrollMonth struct {
+	Month  string         `json:"month"`
+	Cats   []*PayrollLine `json:"cats"`
+	Totals []money.Money  `json:"totals"`
+}
+
+type PayrollReport struct {
+	From   string          `json:"from"`
+	To     string          `json:"to"`
+	Months []*PayrollMonth `json:"months"`
+	Cats   []*PayrollLine  `json:"cats"`
+	Totals []money.Money   `json:"totals"`
+}
+
+type PayrollService interface {
+	Report(ctx context.Context, input PayrollInput) (*PayrollReport, error)
+}
+
+type PayrollServiceImpl struct {
+	catStore storage.CatStorage
+}
+
+func NewPayrollService(catStore storage.CatStorage) *PayrollServiceImpl {
+	return &PayrollServiceImpl{catStore: catStore}
+}
+
+func (s *PayrollServiceImpl) Report(ctx context.Context, input PayrollInput) (*PayrollReport, error) {
+	from := monthStart(input.From)
+	to := monthStart(input.To)
+	until := to.AddDate(0, 1, 0)
+
+	cats, err := s.catStore.All(ctx, models.CatFilter{})
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.catStore.SalaryChangesUntil(ctx, until)
+	if err != nil {
+		return nil, err
+	}
+
+	byCat := make(map[uuid.UUID][]*models.SalaryChange)
+	for _, ch := range changes {
+		byCat[ch.CatID] = append(byCat[ch.CatID], ch)
+	}
+
+	report := &PayrollReport{
+		From:   from.Format(monthLayout),
+		To:     to.Format(monthLayout),
+		Months: []*PayrollMonth{},
+		Cats:   []*PayrollLine{},
+		Totals: []money.Money{},
+	}
+	totals := make(map[string]*PayrollLine)
+
+	for start := from; start.Before(until); start = start.AddDate(0, 1, 0) {
+		end := start.AddDate(0, 1, 0)
+		month := &PayrollMonth{Month: start.Format(monthLayout), Cats: []*PayrollLine{}, Totals: []money.Money{}}
+
+		for _, cat := range cats {
+			paidUntil := end
+			if cat.RetiredAt != nil {
+				paidUntil = earlier(end, *cat.RetiredAt)
+			}
+			for _, amount := range monthlySalary(byCat[cat.ID], start, end, start, paidUntil) {
+				month.Cats = append(month.Cats, &PayrollLine{CatId: cat.ID, CatName: cat.Name, Amount: amount})
+				month.Totals = money.Accumulate(month.Totals, amount)
+
+				key := cat.ID.String() + amount.Currency
+				total, ok := totals[key]
+				if !ok {
+					total = &PayrollLine{CatId: cat.ID, CatName: cat.Name, Amount: money.New(0, amount.Currency)}
+					totals[key] = total
+					report.Cats = append(report.Cats, total)
+				}
+				total.Amount.Amount += amount.Amount
+			}
+		}
+
+		report.Months = append(report.Months, month)
+		for _, t := range month.Totals {
+			report.Totals = money.Accumulate(report.Totals, t)
+		}
+	}
+
+	return report, nil
+}
+
+const monthLayout = "2006-01"
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+type salarySegment struct {
+	salary   money.Money
+	from, to time.Time
+}
+
+func salarySegments(changes []*models.SalaryChange, start, end time.Time) []salarySegment {
+	segments := []salarySegment{}
+	for i, ch := range changes {
+		segStart, segEnd := ch.EffectiveFrom, end
+		if i+1 < len(changes) {
+			segEnd = changes[i+1].EffectiveFrom
+		}
+		if segStart.Before(start) {
+			segStart = start
+		}
+		if segEnd.After(end) {
+			segEnd = end
+		}
+		if !segEnd.After(segStart) {
+			continue
+		}
+		segments = append(segments, salarySegment{salary: ch.Salary, from: segStart, to: segEnd})
+	}
+	return segments
+}
+
+func monthlySalary(changes []*models.SalaryChange, start, end, paidFrom, paidUntil time.Time) []money.Money {
+	minutes := int64(end.Sub(start) / time.Minute)
+
+	amounts := []money.Money{}
+	for _, seg := range salarySegments(changes, paidFrom, paidUntil) {
+		segMinutes := int64(seg.to.Sub(seg.from) / time.Minute)
+		amounts = money.Accumulate(amounts, money.New(seg.salary.Amount.MulDiv(segMinutes, minutes), seg.salary.Currency))
+	}
+
+	return amounts
+}
+
+func proratedSalary(changes []*models.SalaryChange, from, to time.Time) []money.Money {
+	amounts := []money.Money{}
+	for start := monthStart(from); start.Before(to); start = start.AddDate(0, 1, 0) {
+		end := start.AddDate(0, 1, 0)
+		for _, amount := range monthlySalary(changes, start, end, later(start, from), earlier(end, to)) {
+			amounts = money.Accumulate(amounts, amount)
+		}
+	}
+	return amounts
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
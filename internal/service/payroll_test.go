@@ -0,0 +1,151 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"sca/internal/models"
+	"sca/pkg/money"
+)
+
+func TestMonthlySalary(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	change := func(amount money.Amount, currency string, from time.Time) *models.SalaryChange {
+		return &models.SalaryChange{Salary: money.New(amount, currency), EffectiveFrom: from}
+	}
+
+	tests := []struct {
+		name       string
+		changes    []*models.SalaryChange
+		start, end time.Time
+		paidUntil  time.Time
+		want       []money.Money
+	}{
+		{
+			name:    "no changes",
+			changes: nil,
+			start:   date(time.April, 1),
+			end:     date(time.May, 1),
+			want:    []money.Money{},
+		},
+		{
+			name:    "salary set before the month",
+			changes: []*models.SalaryChange{change(300000, "USD", date(time.January, 15))},
+			start:   date(time.April, 1),
+			end:     date(time.May, 1),
+			want:    []money.Money{money.New(300000, "USD")},
+		},
+		{
+			name:    "salary set after the month",
+			changes: []*models.SalaryChange{change(300000, "USD", date(time.May, 1))},
+			start:   date(time.April, 1),
+			end:     date(time.May, 1),
+			want:    []money.Money{},
+		},
+		{
+			name:    "first salary starts mid month",
+			changes: []*models.SalaryChange{change(300000, "USD", date(time.April, 21))},
+			start:   date(time.April, 1),
+			end:     date(time.May, 1),
+			want:    []money.Money{money.New(100000, "USD")},
+		},
+		{
+			name: "raise mid month",
+			changes: []*models.SalaryChange{
+				change(300000, "USD", date(time.January, 1)),
+				change(600000, "USD", date(time.April, 16)),
+			},
+			start: date(time.April, 1),
+			end:   date(time.May, 1),
+			want:  []money.Money{money.New(450000, "USD")},
+		},
+		{
+			name: "later changes do not leak into earlier months",
+			changes: []*models.SalaryChange{
+				change(300000, "USD", date(time.January, 1)),
+				change(600000, "USD", date(time.June, 1)),
+			},
+			start: date(time.April, 1),
+			end:   date(time.May, 1),
+			want:  []money.Money{money.New(300000, "USD")},
+		},
+		{
+			name: "currency change mid month",
+			changes: []*models.SalaryChange{
+				change(300000, "USD", date(time.January, 1)),
+				change(270000, "EUR", date(time.April, 11)),
+			},
+			start: date(time.April, 1),
+			end:   date(time.May, 1),
+			want:  []money.Money{money.New(100000, "USD"), money.New(180000, "EUR")},
+		},
+		{
+			name:      "retired mid month",
+			changes:   []*models.SalaryChange{change(300000, "USD", date(time.January, 1))},
+			start:     date(time.April, 1),
+			end:       date(time.May, 1),
+			paidUntil: date(time.April, 11).Add(12 * time.Hour),
+			want:      []money.Money{money.New(105000, "USD")},
+		},
+		{
+			name:      "retired before the month",
+			changes:   []*models.SalaryChange{change(300000, "USD", date(time.January, 1))},
+			start:     date(time.April, 1),
+			end:       date(time.May, 1),
+			paidUntil: date(time.March, 20),
+			want:      []money.Money{},
+		},
+		{
+			name:    "partial month rounds to the nearest cent",
+			changes: []*models.SalaryChange{change(100000, "USD", date(time.March, 22))},
+			start:   date(time.March, 1),
+			end:     date(time.April, 1),
+			want:    []money.Money{money.New(32258, "USD")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paidUntil := tt.end
+			if !tt.paidUntil.IsZero() {
+				paidUntil = tt.paidUntil
+			}
+			got := monthlySalary(tt.changes, tt.start, tt.end, tt.start, paidUntil)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("monthlySalary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProratedSalary(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	changes := []*models.SalaryChange{
+		{Salary: money.New(300000, "USD"), EffectiveFrom: date(time.January, 1)},
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     []money.Money
+	}{
+		{"whole calendar month matches payroll", date(time.April, 1), date(time.May, 1), []money.Money{money.New(300000, "USD")}},
+		{"spans two months", date(time.March, 16), date(time.April, 16), []money.Money{money.New(304839, "USD")}},
+		{"half a day", date(time.April, 2), date(time.April, 2).Add(12 * time.Hour), []money.Money{money.New(5000, "USD")}},
+		{"before the first salary", date(2, 1).AddDate(-1, 0, 0), date(2, 1).AddDate(-1, 0, 1), []money.Money{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := proratedSalary(changes, tt.from, tt.to)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("proratedSalary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/errors"
+	"sca/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FactorExperience = "experience"
+	FactorBreed      = "breed"
+	FactorWorkload   = "workload"
+	FactorCountry    = "country"
+	FactorCost       = "cost"
+)
+
+type ScoringModel struct {
+	Experience    float64
+	Breed         float64
+	Workload      float64
+	Country       float64
+	Cost          float64
+	MaxExperience int
+	DefaultBreed  float64
+	Breeds        map[string]float64
+}
+
+func DefaultScoringModel() ScoringModel {
+	return ScoringModel{
+		Experience:    0.3,
+		Breed:         0.1,
+		Workload:      0.2,
+		Country:       0.25,
+		Cost:          0.15,
+		MaxExperience: 10,
+		DefaultBreed:  0.5,
+	}
+}
+
+func (m ScoringModel) weights() map[string]float64 {
+	return map[string]float64{
+		FactorExperience: m.Experience,
+		FactorBreed:      m.Breed,
+		FactorWorkload:   m.Workload,
+		FactorCountry:    m.Country,
+		FactorCost:       m.Cost,
+	}
+}
+
+type RecommendCatsInput struct {
+	MissionId uuid.UUID
+	Limit     int
+}
+
+type ScoreFactor struct {
+	Value        float64 `json:"value"`
+	Weight       float64 `json:"weight"`
+	Contribution float64 `json:"contribution"`
+}
+
+type CatRecommendation struct {
+	Cat           *models.Cat             `json:"cat"`
+	Score         float64                 `json:"score"`
+	Breakdown     map[string]*ScoreFactor `json:"breakdown"`
+	MissingSkills []string                `json:"missing_skills"`
+}
+
+type RecommendService interface {
+	RecommendCats(ctx context.Context, input RecommendCatsInput) ([]*CatRecommendation, error)
+}
+
+type RecommendServiceImpl struct {
+	missionStore storage.MissionStorage
+	catStore     storage.CatStorage
+	model        ScoringModel
+}
+
+func NewRecommendService(missionStore storage.MissionStorage, catStore storage.CatStorage, model ScoringModel) *RecommendServiceImpl {
+	defaults := DefaultScoringModel()
+	if model.Experience == 0 && model.Breed == 0 && model.Workload == 0 && model.Country == 0 && model.Cost == 0 {
+		model.Experience = defaults.Experience
+		model.Breed = defaults.Breed
+		model.Workload = defaults.Workload
+		model.Country = defaults.Country
+		model.Cost = defaults.Cost
+	}
+	if model.MaxExperience <= 0 {
+		model.MaxExperience = defaults.MaxExperience
+	}
+	if model.DefaultBreed == 0 {
+		model.DefaultBreed = defaults.DefaultBreed
+	}
+
+	return &RecommendServiceImpl{
+		missionStore: missionStore,
+		catStore:     catStore,
+		model:        model,
+	}
+}
+
+func (s *RecommendServiceImpl) RecommendCats(ctx context.Context, input RecommendCatsInput) ([]*CatRecommendation, error) {
+	mission, err := s.missionStore.ById(ctx, input.MissionId)
+	if err != nil {
+		return nil, err
+	}
+	if mission.Status.IsFinal() {
+		return nil, errors.ErrConflict{Msg: "Cannot recommend cats: mission is " + string(mission.Status)}
+	}
+
+	from := time.Now().UTC()
+	to := from
+	if mission.DueAt != nil && mission.DueAt.After(from) {
+		to = *mission.DueAt
+	}
+	cats, err := s.catStore.Available(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := s.catStore.Workloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	active := make(map[uuid.UUID]int, len(workloads))
+	for _, w := range workloads {
+		active[w.CatID] = w.Active
+	}
+
+	countries := []string{}
+	targetsByCountry := map[string]int{}
+	for _, t := range mission.Targets {
+		if targetsByCountry[t.Country] == 0 {
+			countries = append(countries, t.Country)
+		}
+		targetsByCountry[t.Country]++
+	}
+	records, err := s.catStore.CountryRecords(ctx, countries)
+	if err != nil {
+		return nil, err
+	}
+	history := map[uuid.UUID]map[string]*models.CatCountryRecord{}
+	for _, r := range records {
+		if history[r.CatID] == nil {
+			history[r.CatID] = map[string]*models.CatCountryRecord{}
+		}
+		history[r.CatID][r.Country] = r
+	}
+
+	cheapest := map[string]money.Amount{}
+	for _, c := range cats {
+		if lowest, ok := cheapest[c.Salary.Currency]; !ok || c.Salary.Amount < lowest {
+			cheapest[c.Salary.Currency] = c.Salary.Amount
+		}
+	}
+
+	required := requiredSkills(mission.Targets)
+	weights := s.model.weights()
+	var totalWeight float64
+	for _, w := range weights {
+		totalWeight += w
+	}
+
+	recommendations := make([]*CatRecommendation, 0, len(cats))
+	for _, c := range cats {
+		values := map[string]float64{
+			FactorExperience: math.Min(float64(c.YearsOfExperience)/float64(s.model.MaxExperience), 1),
+			FactorBreed:      s.breedScore(c.Breed),
+			FactorWorkload:   1 / float64(1+active[c.ID]),
+			FactorCountry:    countryScore(history[c.ID], targetsByCountry, len(mission.Targets)),
+			FactorCost:       costScore(c.Salary, cheapest[c.Salary.Currency]),
+		}
+
+		rec := &CatRecommendation{
+			Cat:           c,
+			Breakdown:     make(map[string]*ScoreFactor, len(values)),
+			MissingSkills: c.MissingSkills(required),
+		}
+		for name, value := range values {
+			factor := &ScoreFactor{
+				Value:  roundScore(value),
+				Weight: weights[name],
+			}
+			if totalWeight > 0 {
+				factor.Contribution = roundScore(value * weights[name] / totalWeight)
+			}
+			rec.Score += factor.Contribution
+			rec.Breakdown[name] = factor
+		}
+		rec.Score = roundScore(rec.Score)
+		recommendations = append(recommendations, rec)
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		if mi, mj := len(recommendations[i].MissingSkills), len(recommendations[j].MissingSkills); mi != mj {
+			return mi < mj
+		}
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score > recommendations[j].Score
+		}
+		return recommendations[i].Cat.Name < recommendations[j].Cat.Name
+	})
+	if input.Limit > 0 && len(recommendations) > input.Limit {
+		recommendations = recommendations[:input.Limit]
+	}
+
+	return recommendations, nil
+}
+
+func (s *RecommendServiceImpl) breedScore(breed string) float64 {
+	if score, ok := s.model.Breeds[breed]; ok {
+		return math.Max(0, math.Min(score, 1))
+	}
+	return s.model.DefaultBreed
+}
+
+func countryScore(records map[string]*models.CatCountryRecord, targetsByCountry map[string]int, targets int) float64 {
+	if targets == 0 {
+		return 0.5
+	}
+
+	var score float64
+	for country, count := range targetsByCountry {
+		completed, total := 0, 0
+		if r, ok := records[country]; ok {
+			completed, total = r.Completed, r.Total
+		}
+		rate := float64(completed+1) / float64(total+2)
+		score += rate * float64(count)
+	}
+	return score / float64(targets)
+}
+
+func costScore(salary money.Money, cheapest money.Amount) float64 {
+	if salary.Amount <= 0 {
+		return 1
+	}
+	return float64(cheapest) / float64(salary.Amount)
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
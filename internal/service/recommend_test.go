@@ -0,0 +1,111 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"sca/internal/models"
+	"sca/pkg/money"
+)
+
+func TestCountryScore(t *testing.T) {
+	records := map[string]*models.CatCountryRecord{
+		"FR": {Country: "FR", Completed: 8, Total: 8},
+		"DE": {Country: "DE", Completed: 0, Total: 4},
+		"IT": {Country: "IT", Completed: 3, Total: 4},
+	}
+
+	tests := []struct {
+		name             string
+		records          map[string]*models.CatCountryRecord
+		targetsByCountry map[string]int
+		targets          int
+		want             float64
+	}{
+		{
+			name:    "no targets is neutral",
+			records: records,
+			want:    0.5,
+		},
+		{
+			name:             "no history is neutral",
+			records:          nil,
+			targetsByCountry: map[string]int{"FR": 2},
+			targets:          2,
+			want:             0.5,
+		},
+		{
+			name:             "perfect record",
+			records:          records,
+			targetsByCountry: map[string]int{"FR": 1},
+			targets:          1,
+			want:             0.9,
+		},
+		{
+			name:             "failed record",
+			records:          records,
+			targetsByCountry: map[string]int{"DE": 1},
+			targets:          1,
+			want:             1.0 / 6,
+		},
+		{
+			name:             "weighted by targets per country",
+			records:          records,
+			targetsByCountry: map[string]int{"FR": 1, "IT": 2, "ES": 1},
+			targets:          4,
+			want:             (0.9 + 2*(4.0/6) + 0.5) / 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := countryScore(tt.records, tt.targetsByCountry, tt.targets)
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("countryScore() = %v, want %v", got, tt.want)
+			}
+			if got < 0 || got > 1 {
+				t.Errorf("countryScore() = %v, want a value in [0, 1]", got)
+			}
+		})
+	}
+}
+
+func TestCostScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		salary   money.Money
+		cheapest money.Amount
+		want     float64
+	}{
+		{name: "cheapest cat", salary: money.New(100000, "USD"), cheapest: 100000, want: 1},
+		{name: "twice the cheapest", salary: money.New(200000, "USD"), cheapest: 100000, want: 0.5},
+		{name: "four times the cheapest", salary: money.New(400000, "USD"), cheapest: 100000, want: 0.25},
+		{name: "unpaid cat", salary: money.New(0, "USD"), cheapest: 0, want: 1},
+		{name: "unpaid cheapest", salary: money.New(100000, "USD"), cheapest: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := costScore(tt.salary, tt.cheapest); got != tt.want {
+				t.Errorf("costScore(%v, %v) = %v, want %v", tt.salary, tt.cheapest, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundScore(t *testing.T) {
+	tests := []struct {
+		in, want float64
+	}{
+		{0.12345, 0.123},
+		{0.1235, 0.124},
+		{1, 1},
+		{1.0 / 3, 0.333},
+	}
+
+	for _, tt := range tests {
+		if got := roundScore(tt.in); got != tt.want {
+			t.Errorf("roundScore(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
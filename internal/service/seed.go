@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"sca/internal/models"
+	"sca/pkg/errors"
+	"sca/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxSeedCats     = 10000
+	maxSeedMissions = 100000
+)
+
+type SeedInput struct {
+	Seed     uint64
+	Cats     int
+	Missions int
+	Epoch    time.Time
+}
+
+type SeedFixture struct {
+	Seed     uint64           `json:"seed"`
+	Epoch    time.Time        `json:"epoch"`
+	Cats     []*catRecord     `json:"cats"`
+	Missions []*missionRecord `json:"missions"`
+}
+
+type SeedResult struct {
+	Cats     *ImportResult `json:"cats"`
+	Missions *ImportResult `json:"missions"`
+}
+
+type SeedService interface {
+	Generate(input SeedInput) (*SeedFixture, error)
+	Load(ctx context.Context, fixture *SeedFixture) (*SeedResult, error)
+}
+
+type SeedServiceImpl struct {
+	transfer *TransferServiceImpl
+}
+
+func NewSeedService(transfer *TransferServiceImpl) *SeedServiceImpl {
+	return &SeedServiceImpl{transfer: transfer}
+}
+
+type seedGenerator struct {
+	source *rand.ChaCha8
+	rng    *rand.Rand
+	epoch  time.Time
+	names  map[string]int
+}
+
+func (s *SeedServiceImpl) Generate(input SeedInput) (*SeedFixture, error) {
+	if input.Cats < 0 || input.Cats > maxSeedCats {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Cannot seed: cats must be between 0 and %d", maxSeedCats)}
+	}
+	if input.Missions < 0 || input.Missions > maxSeedMissions {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Cannot seed: missions must be between 0 and %d", maxSeedMissions)}
+	}
+
+	var key [32]byte
+	binary.LittleEndian.PutUint64(key[:], input.Seed)
+	source := rand.NewChaCha8(key)
+	g := &seedGenerator{
+		source: source,
+		rng:    rand.New(source),
+		epoch:  input.Epoch.UTC(),
+		names:  map[string]int{},
+	}
+
+	fixture := &SeedFixture{
+		Seed:     input.Seed,
+		Epoch:    g.epoch,
+		Cats:     make([]*catRecord, input.Cats),
+		Missions: make([]*missionRecord, input.Missions),
+	}
+
+	active := []*catRecord{}
+	for i := range fixture.Cats {
+		cat := g.cat()
+		fixture.Cats[i] = cat
+		if cat.Status == string(models.CatActive) {
+			active = append(active, cat)
+		}
+	}
+	for i := range fixture.Missions {
+		fixture.Missions[i] = g.mission(active)
+	}
+
+	return fixture, nil
+}
+
+func (s *SeedServiceImpl) Load(ctx context.Context, fixture *SeedFixture) (*SeedResult, error) {
+	records := make([]importRecord, len(fixture.Cats))
+	for i, c := range fixture.Cats {
+		records[i] = c
+	}
+	cats, err := s.load(ctx, TransferCats, records)
+	if err != nil {
+		return nil, err
+	}
+
+	records = make([]importRecord, len(fixture.Missions))
+	for i, m := range fixture.Missions {
+		records[i] = m
+	}
+	missions, err := s.load(ctx, TransferMissions, records)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeedResult{Cats: cats, Missions: missions}, nil
+}
+
+func (s *SeedServiceImpl) load(ctx context.Context, entity TransferEntity, records []importRecord) (*ImportResult, error) {
+	result := &ImportResult{
+		Entity: entity,
+		Format: FormatJSON,
+		Errors: []*ImportRowError{},
+	}
+	for i, record := range records {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		created, err := s.transfer.createRecord(ctx, record)
+		if err != nil {
+			result.fail(i+1, record.recordId(), err)
+			continue
+		}
+		if created {
+			result.Created++
+		} else {
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+func (g *seedGenerator) id() uuid.UUID {
+	id, err := uuid.NewRandomFromReader(g.source)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+func (g *seedGenerator) pick(items []string) string {
+	return items[g.rng.IntN(len(items))]
+}
+
+func (g *seedGenerator) chance(percent int) bool {
+	return g.rng.IntN(100) < percent
+}
+
+func (g *seedGenerator) skills(max int) []string {
+	n := g.rng.IntN(max + 1)
+	skills := []string{}
+	for _, i := range g.rng.Perm(len(seedSkills))[:n] {
+		skills = append(skills, seedSkills[i])
+	}
+	return skills
+}
+
+func (g *seedGenerator) name() string {
+	name := g.pick(seedCatTitles) + " " + g.pick(seedCatNames)
+	g.names[name]++
+	if n := g.names[name]; n > 1 {
+		name = fmt.Sprintf("%s %d", name, n)
+	}
+	return name
+}
+
+func (g *seedGenerator) cat() *catRecord {
+	status := models.CatActive
+	switch n := g.rng.IntN(100); {
+	case n < 5:
+		status = models.CatRetired
+	case n < 12:
+		status = models.CatOnLeave
+	}
+
+	return &catRecord{
+		ID:                g.id(),
+		Name:              g.name(),
+		YearsOfExperience: g.rng.IntN(11),
+		Breed:             g.pick(seedBreeds),
+		Status:            string(status),
+		Salary:            money.New(money.Amount(100_000+g.rng.IntN(80)*5_000), money.DefaultCurrency),
+		Skills:            g.skills(4),
+	}
+}
+
+func (g *seedGenerator) mission(active []*catRecord) *missionRecord {
+	status := models.MissionDraft
+	if len(active) > 0 {
+		switch n := g.rng.IntN(100); {
+		case n < 15:
+			status = models.MissionDraft
+		case n < 40:
+			status = models.MissionAssigned
+		case n < 65:
+			status = models.MissionInProgress
+		case n < 90:
+			status = models.MissionCompleted
+		case n < 95:
+			status = models.MissionAborted
+		default:
+			status = models.MissionFailed
+		}
+	}
+
+	dueAt := g.epoch.AddDate(0, 0, g.rng.IntN(90)-30)
+	mission := &missionRecord{
+		ID:       g.id(),
+		Status:   string(status),
+		Priority: 1 + g.rng.IntN(5),
+		DueAt:    &dueAt,
+		Budget:   money.New(money.Amount(50_000+g.rng.IntN(100)*10_000), money.DefaultCurrency),
+	}
+	if status != models.MissionDraft {
+		catId := active[g.rng.IntN(len(active))].ID
+		mission.CatId = &catId
+	}
+
+	n := 1 + g.rng.IntN(models.MaxMissionTargets)
+	for i := range n {
+		target := g.target(i)
+		switch status {
+		case models.MissionCompleted:
+			target.Complete = true
+		case models.MissionInProgress, models.MissionFailed:
+			target.Complete = i < n-1 && g.chance(50)
+		}
+		mission.Targets = append(mission.Targets, target)
+	}
+	return mission
+}
+
+func (g *seedGenerator) target(position int) *targetRecord {
+	country := seedCountries[g.rng.IntN(len(seedCountries))]
+	latitude := jitter(g.rng, country.latitude, 2, 90)
+	longitude := jitter(g.rng, country.longitude, 2, 180)
+	lastSeenAt := g.epoch.Add(-time.Duration(g.rng.IntN(24*60)) * time.Hour)
+
+	return &targetRecord{
+		ID:             g.id(),
+		Position:       position,
+		Name:           g.pick(seedTargetAdjectives) + " " + g.pick(seedTargetNouns),
+		Country:        country.code,
+		Latitude:       &latitude,
+		Longitude:      &longitude,
+		LastSeenAt:     &lastSeenAt,
+		Notes:          g.pick(seedTargetNotes),
+		RequiredSkills: g.skills(2),
+	}
+}
+
+func jitter(rng *rand.Rand, value, spread, limit float64) float64 {
+	v := value + (rng.Float64()*2-1)*spread
+	v = math.Max(-limit, math.Min(limit, v))
+	return math.Round(v*1e6) / 1e6
+}
@@ -0,0 +1,71 @@
+package service
+
+type seedCountry struct {
+	code      string
+	latitude  float64
+	longitude float64
+}
+
+var (
+	seedBreeds = []string{
+		"Abyssinian", "Aegean", "American Bobtail", "American Curl", "American Shorthair",
+		"Balinese", "Bengal", "Birman", "Bombay", "British Shorthair",
+		"Burmese", "Chartreux", "Cornish Rex", "Devon Rex", "Egyptian Mau",
+		"Exotic Shorthair", "Havana Brown", "Himalayan", "Japanese Bobtail", "Maine Coon",
+		"Manx", "Norwegian Forest Cat", "Ocicat", "Oriental", "Persian",
+		"Ragdoll", "Russian Blue", "Savannah", "Scottish Fold", "Siamese",
+		"Siberian", "Singapura", "Somali", "Sphynx", "Tonkinese",
+		"Turkish Angora", "Turkish Van",
+	}
+
+	seedCatNames = []string{
+		"Whiskers", "Shadow", "Mittens", "Luna", "Oliver", "Smokey", "Tiger", "Cleo",
+		"Jasper", "Nala", "Pepper", "Ziggy", "Misty", "Felix", "Salem", "Ginger",
+		"Biscuit", "Mochi", "Pixel", "Onyx", "Saffron", "Tofu", "Velvet", "Ember",
+	}
+
+	seedCatTitles = []string{"Agent", "Captain", "Major", "Sergeant", "Doctor", "Professor", "Lady", "Sir"}
+
+	seedSkills = []string{
+		"stealth", "surveillance", "lockpicking", "climbing", "disguise",
+		"hacking", "negotiation", "tracking", "demolition", "night-vision",
+	}
+
+	seedTargetAdjectives = []string{"Silent", "Crimson", "Golden", "Iron", "Midnight", "Velvet", "Broken", "Hidden", "Frozen", "Scarlet"}
+
+	seedTargetNouns = []string{"Falcon", "Mouse", "Canary", "Viper", "Fox", "Raven", "Badger", "Jackal", "Otter", "Heron"}
+
+	seedTargetNotes = []string{
+		"Last seen near the harbour after dark",
+		"Frequents the central market on weekends",
+		"Known to carry a laser pointer",
+		"Keeps a stash of catnip in a safehouse",
+		"Travels with two bodyguards",
+		"Avoids cameras and crowded places",
+		"Meets contacts at a rooftop cafe",
+		"Uses a rotating set of disguises",
+	}
+
+	seedCountries = []seedCountry{
+		{code: "US", latitude: 39.8, longitude: -98.6},
+		{code: "CA", latitude: 56.1, longitude: -106.3},
+		{code: "BR", latitude: -14.2, longitude: -51.9},
+		{code: "GB", latitude: 54.0, longitude: -2.0},
+		{code: "FR", latitude: 46.2, longitude: 2.2},
+		{code: "DE", latitude: 51.2, longitude: 10.5},
+		{code: "IT", latitude: 41.9, longitude: 12.6},
+		{code: "ES", latitude: 40.5, longitude: -3.7},
+		{code: "UA", latitude: 48.4, longitude: 31.2},
+		{code: "PL", latitude: 51.9, longitude: 19.1},
+		{code: "TR", latitude: 39.0, longitude: 35.2},
+		{code: "EG", latitude: 26.8, longitude: 30.8},
+		{code: "ZA", latitude: -30.6, longitude: 22.9},
+		{code: "IN", latitude: 20.6, longitude: 79.0},
+		{code: "CN", latitude: 35.9, longitude: 104.2},
+		{code: "JP", latitude: 36.2, longitude: 138.3},
+		{code: "AU", latitude: -25.3, longitude: 133.8},
+		{code: "MX", latitude: 23.6, longitude: -102.6},
+		{code: "AR", latitude: -38.4, longitude: -63.6},
+		{code: "SE", latitude: 60.1, longitude: 18.6},
+	}
+)
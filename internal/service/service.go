@@ -2,24 +2,66 @@ package service
 
 import (
 	"sca/internal/storage"
+	"sca/pkg/blob"
 	"sca/pkg/cache"
+	"sca/pkg/notify"
 )
 
 type Depends struct {
-	Storage *storage.Storage
-	Cache   cache.Cache
+	Storage     *storage.Storage
+	Cache       cache.Cache
+	Notifier    notify.Notifier
+	Scoring     ScoringModel
+	Blobs       blob.Store
+	Attachments AttachmentOptions
+	Comments    CommentOptions
+	Debriefs    DebriefOptions
+	Dashboard   DashboardOptions
+	Validator   StructValidator
 }
 
 type Service struct {
-	Cats     CatService
-	Missions MissionService
-	Targets  TargetService
+	Cats        CatService
+	Missions    MissionService
+	Targets     TargetService
+	Payroll     PayrollService
+	Costs       CostService
+	Templates   TemplateService
+	Attachments AttachmentService
+	Comments    CommentService
+	Debriefs    DebriefService
+	Transfer    TransferService
+	Seed        SeedService
+	Backup      BackupService
+	Recommend   RecommendService
+	Dashboard   DashboardService
+	Overdue     *OverdueChecker
+	Dispatcher  *Dispatcher
 }
 
 func NewService(depends *Depends) *Service {
+	missions := NewMissionService(depends.Storage.MissionStorage, depends.Storage.CatStorage, depends.Storage.TargetStorage, depends.Storage.TemplateStorage, depends.Storage.DebriefStorage, depends.Blobs, depends.Cache, depends.Debriefs.Required)
+	cats := NewCatService(depends.Storage.CatStorage, depends.Cache)
+	targets := NewTargetService(depends.Storage.TargetStorage, depends.Storage.MissionStorage, depends.Storage.DebriefStorage, depends.Blobs, depends.Cache, depends.Debriefs.Required)
+	recommend := NewRecommendService(depends.Storage.MissionStorage, depends.Storage.CatStorage, depends.Scoring)
+	transfer := NewTransferService(cats, missions, targets, depends.Validator)
+
 	return &Service{
-		Cats:     NewCatService(depends.Storage.CatStorage, depends.Cache),
-		Missions: NewMissionService(depends.Storage.MissionStorage, depends.Storage.CatStorage, depends.Storage.TargetStorage, depends.Cache),
-		Targets:  NewTargetService(depends.Storage.TargetStorage, depends.Storage.MissionStorage, depends.Cache),
+		Cats:        cats,
+		Missions:    missions,
+		Targets:     targets,
+		Payroll:     NewPayrollService(depends.Storage.CatStorage),
+		Costs:       NewCostService(depends.Storage.MissionStorage, depends.Storage.CatStorage, depends.Notifier),
+		Templates:   NewTemplateService(depends.Storage.TemplateStorage, depends.Cache),
+		Attachments: NewAttachmentService(depends.Storage.AttachmentStorage, depends.Storage.MissionStorage, depends.Storage.TargetStorage, depends.Blobs, depends.Attachments),
+		Comments:    NewCommentService(depends.Storage.CommentStorage, depends.Storage.MissionStorage, depends.Storage.TargetStorage, depends.Notifier, depends.Comments),
+		Debriefs:    NewDebriefService(depends.Storage.DebriefStorage, depends.Storage.MissionStorage, depends.Storage.CatStorage, depends.Storage.TargetStorage, depends.Cache, depends.Debriefs.Required),
+		Transfer:    transfer,
+		Seed:        NewSeedService(transfer),
+		Backup:      NewBackupService(depends.Storage.BackupStorage, depends.Blobs, depends.Cache),
+		Recommend:   recommend,
+		Dashboard:   NewDashboardService(depends.Storage.DashboardStorage, depends.Cache, depends.Dashboard),
+		Overdue:     NewOverdueChecker(depends.Storage.MissionStorage, depends.Notifier, depends.Cache),
+		Dispatcher:  NewDispatcher(depends.Storage.MissionStorage, depends.Storage.LockStorage, missions, recommend, depends.Notifier),
 	}
 }
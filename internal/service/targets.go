@@ -2,61 +2,130 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"sca/internal/models"
 	"sca/internal/storage"
+	"sca/pkg/blob"
 	"sca/pkg/cache"
+	"sca/pkg/country"
+	"sca/pkg/diff"
 	"sca/pkg/errors"
 
 	"github.com/google/uuid"
 )
 
 type CreateTargetInput struct {
-	Name    string
-	Country string
-	Notes   string
+	ID             uuid.UUID
+	Name           string
+	Country        string
+	Latitude       *float64
+	Longitude      *float64
+	LastSeenAt     *time.Time
+	Notes          string
+	RequiredSkills []string
+}
+
+type BulkCreateTargetsInput struct {
+	Mode    BulkMode
+	Items   []CreateTargetInput
+	Invalid map[int]error
+}
+
+type UpdateLocationInput struct {
+	ID         uuid.UUID
+	Latitude   float64
+	Longitude  float64
+	LastSeenAt *time.Time
+}
+
+type NearbyInput struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64
 }
 
 type UpdateNotesInput struct {
-	ID    uuid.UUID
-	Notes string
+	ID     uuid.UUID
+	Notes  string
+	Author string
+}
+
+type NotesDiffInput struct {
+	ID   uuid.UUID
+	From int
+	To   int
+}
+
+type RestoreNotesInput struct {
+	ID      uuid.UUID
+	Version int
+	Author  string
+}
+
+type NotesDiff struct {
+	From    *models.NoteRevision `json:"from"`
+	To      *models.NoteRevision `json:"to"`
+	Changes []diff.Change        `json:"changes"`
 }
 
 type TargetService interface {
 	Create(ctx context.Context, input CreateTargetInput) (*models.Target, error)
+	BulkCreate(ctx context.Context, input BulkCreateTargetsInput) (*BulkResult, error)
 	ById(ctx context.Context, id uuid.UUID) (*models.Target, error)
 	All(ctx context.Context) ([]*models.Target, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	MarkComplete(ctx context.Context, id uuid.UUID) error
 	UpdateNotes(ctx context.Context, input UpdateNotesInput) error
+	NotesHistory(ctx context.Context, id uuid.UUID) ([]*models.NoteRevision, error)
+	NotesDiff(ctx context.Context, input NotesDiffInput) (*NotesDiff, error)
+	RestoreNotes(ctx context.Context, input RestoreNotesInput) error
+	UpdateLocation(ctx context.Context, input UpdateLocationInput) (*models.Target, error)
+	Nearby(ctx context.Context, input NearbyInput) ([]*models.NearbyTarget, error)
+	UpdateRequiredSkills(ctx context.Context, id uuid.UUID, skills []string) (*models.Target, error)
 }
 
 type TargetServiceImpl struct {
-	store        storage.TargetStorage
-	missionStore storage.MissionStorage
-	cache        cache.Cache
+	store          storage.TargetStorage
+	missionStore   storage.MissionStorage
+	debriefStore   storage.DebriefStorage
+	blobs          blob.Store
+	cache          cache.Cache
+	requireDebrief bool
 }
 
-func NewTargetService(store storage.TargetStorage, missionStore storage.MissionStorage, cache cache.Cache) *TargetServiceImpl {
+func NewTargetService(store storage.TargetStorage, missionStore storage.MissionStorage, debriefStore storage.DebriefStorage, blobs blob.Store, cache cache.Cache, requireDebrief bool) *TargetServiceImpl {
 	return &TargetServiceImpl{
-		store:        store,
-		missionStore: missionStore,
-		cache:        cache,
+		store:          store,
+		missionStore:   missionStore,
+		debriefStore:   debriefStore,
+		blobs:          blobs,
+		cache:          cache,
+		requireDebrief: requireDebrief,
 	}
 }
 
 func (s *TargetServiceImpl) Create(ctx context.Context, input CreateTargetInput) (*models.Target, error) {
 	const cacheKey = "targets"
 
+	countryCode, err := normalizeCountry(input.Country)
+	if err != nil {
+		return nil, err
+	}
+
 	target := &models.Target{
-		ID:      uuid.New(),
-		Name:    input.Name,
-		Country: input.Country,
-		Notes:   input.Notes,
+		ID:             newId(input.ID),
+		Name:           input.Name,
+		Country:        countryCode,
+		Latitude:       input.Latitude,
+		Longitude:      input.Longitude,
+		LastSeenAt:     input.LastSeenAt,
+		Notes:          input.Notes,
+		RequiredSkills: uniqueSkills(input.RequiredSkills),
 	}
 
-	err := s.store.Create(ctx, target)
+	err = s.store.Create(ctx, target)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +135,59 @@ func (s *TargetServiceImpl) Create(ctx context.Context, input CreateTargetInput)
 	return target, nil
 }
 
+func (s *TargetServiceImpl) BulkCreate(ctx context.Context, input BulkCreateTargetsInput) (*BulkResult, error) {
+	const cacheKey = "targets"
+
+	if err := checkBulkSize(len(input.Items)); err != nil {
+		return nil, err
+	}
+
+	result := newBulkResult(input.Mode, len(input.Items))
+	targets := make([]*models.Target, 0, len(input.Items))
+	ids := make([]uuid.UUID, 0, len(input.Items))
+	indexes := make([]int, 0, len(input.Items))
+	for i, item := range input.Items {
+		if err := input.Invalid[i]; err != nil {
+			result.fail(i, err)
+			continue
+		}
+		countryCode, err := normalizeCountry(item.Country)
+		if err != nil {
+			result.fail(i, err)
+			continue
+		}
+		target := &models.Target{
+			ID:             uuid.New(),
+			Name:           item.Name,
+			Country:        countryCode,
+			Latitude:       item.Latitude,
+			Longitude:      item.Longitude,
+			LastSeenAt:     item.LastSeenAt,
+			Notes:          item.Notes,
+			RequiredSkills: uniqueSkills(item.RequiredSkills),
+		}
+		targets = append(targets, target)
+		ids = append(ids, target.ID)
+		indexes = append(indexes, i)
+	}
+	if input.Mode == BulkAtomic && result.Failed > 0 {
+		result.rollBack()
+		return result, nil
+	}
+
+	errs, err := s.store.CreateMany(ctx, targets, input.Mode == BulkAtomic)
+	if err != nil {
+		return nil, err
+	}
+	result.apply(indexes, ids, errs)
+
+	if result.Created > 0 {
+		_ = s.cache.Del(ctx, cacheKey)
+	}
+
+	return result, nil
+}
+
 func (s *TargetServiceImpl) ById(ctx context.Context, id uuid.UUID) (*models.Target, error) {
 	target, err := s.store.ById(ctx, id)
 	if err != nil {
@@ -99,10 +221,11 @@ func (s *TargetServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
-	err = s.store.Delete(ctx, id)
+	blobKeys, err := s.store.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
+	deleteBlobs(ctx, s.blobs, blobKeys)
 
 	_ = s.cache.Del(ctx, cacheKey)
 
@@ -110,45 +233,175 @@ func (s *TargetServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (s *TargetServiceImpl) MarkComplete(ctx context.Context, id uuid.UUID) error {
-	const cacheKey = "targets"
+	const (
+		cacheKey        = "targets"
+		missionCacheKey = "missions"
+	)
+
+	missionCompleted, err := s.store.MarkComplete(ctx, id, s.requireDebrief)
+	if err != nil {
+		return err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+	if missionCompleted {
+		_ = s.cache.Del(ctx, missionCacheKey)
+	}
+
+	return nil
+}
+
+func (s *TargetServiceImpl) UpdateNotes(ctx context.Context, input UpdateNotesInput) error {
+	target, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkTargetEditable(ctx, s.missionStore, target, "Cannot update notes"); err != nil {
+		return err
+	}
+
+	return s.saveNotes(ctx, target.ID, input.Notes, input.Author)
+}
 
+func (s *TargetServiceImpl) NotesHistory(ctx context.Context, id uuid.UUID) ([]*models.NoteRevision, error) {
 	_, err := s.ById(ctx, id)
 	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.store.NoteRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (s *TargetServiceImpl) NotesDiff(ctx context.Context, input NotesDiffInput) (*NotesDiff, error) {
+	from, err := s.store.NoteRevision(ctx, input.ID, input.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.store.NoteRevision(ctx, input.ID, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotesDiff{
+		From:    from,
+		To:      to,
+		Changes: diff.Words(from.Notes, to.Notes),
+	}, nil
+}
+
+func (s *TargetServiceImpl) RestoreNotes(ctx context.Context, input RestoreNotesInput) error {
+	target, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return err
+	}
+	if err := checkTargetEditable(ctx, s.missionStore, target, "Cannot restore notes"); err != nil {
 		return err
 	}
 
-	err = s.store.MarkComplete(ctx, id)
+	revision, err := s.store.NoteRevision(ctx, input.ID, input.Version)
 	if err != nil {
 		return err
 	}
 
+	return s.saveNotes(ctx, target.ID, revision.Notes, input.Author)
+}
+
+func (s *TargetServiceImpl) UpdateLocation(ctx context.Context, input UpdateLocationInput) (*models.Target, error) {
+	const cacheKey = "targets"
+
+	target, err := s.ById(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTargetEditable(ctx, s.missionStore, target, "Cannot update location"); err != nil {
+		return nil, err
+	}
+
+	lastSeenAt := time.Now().UTC()
+	if input.LastSeenAt != nil {
+		lastSeenAt = *input.LastSeenAt
+	}
+	target.Latitude = &input.Latitude
+	target.Longitude = &input.Longitude
+	target.LastSeenAt = &lastSeenAt
+
+	err = s.store.UpdateLocation(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+
 	_ = s.cache.Del(ctx, cacheKey)
 
-	return nil
+	return target, nil
 }
 
-func (s *TargetServiceImpl) UpdateNotes(ctx context.Context, input UpdateNotesInput) error {
+func (s *TargetServiceImpl) Nearby(ctx context.Context, input NearbyInput) ([]*models.NearbyTarget, error) {
+	targets, err := s.store.Nearby(ctx, input.Latitude, input.Longitude, input.RadiusKm)
+	if err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+func (s *TargetServiceImpl) UpdateRequiredSkills(ctx context.Context, id uuid.UUID, skills []string) (*models.Target, error) {
 	const cacheKey = "targets"
 
-	target, err := s.ById(ctx, input.ID)
+	target, err := s.ById(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := checkTargetEditable(ctx, s.missionStore, target, "Cannot update required skills"); err != nil {
+		return nil, err
+	}
+
+	target.RequiredSkills = uniqueSkills(skills)
+	err = s.store.SetRequiredSkills(ctx, target.ID, target.RequiredSkills)
+	if err != nil {
+		return nil, err
 	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return target, nil
+}
+
+func checkTargetEditable(ctx context.Context, missionStore storage.MissionStorage, target *models.Target, prefix string) error {
 	if target.Complete {
-		return errors.ErrConflict{Msg: "Cannot update notes: target is completed"}
+		return errors.ErrConflict{Msg: prefix + ": target is completed"}
 	}
 
-	if *target.MissionID != uuid.Nil {
-		mission, err := s.missionStore.ById(ctx, *target.MissionID)
+	if target.MissionID != nil && *target.MissionID != uuid.Nil {
+		mission, err := missionStore.ById(ctx, *target.MissionID)
 		if err != nil {
 			return err
 		}
-		if mission.Complete {
-			return errors.ErrConflict{Msg: "Cannot update notes: mission is completed"}
+		if mission.Status.IsFinal() {
+			return errors.ErrConflict{Msg: fmt.Sprintf("%s: mission is %s", prefix, mission.Status)}
 		}
 	}
 
-	err = s.store.UpdateNotes(ctx, input.ID, input.Notes)
+	return nil
+}
+
+func (s *TargetServiceImpl) saveNotes(ctx context.Context, id uuid.UUID, notes, author string) error {
+	const cacheKey = "targets"
+
+	var authorPtr *string
+	if author != "" {
+		authorPtr = &author
+	}
+
+	err := s.store.UpdateNotes(ctx, &models.NoteRevision{
+		ID:        uuid.New(),
+		TargetID:  id,
+		Notes:     notes,
+		Author:    authorPtr,
+		CreatedAt: time.Now().UTC(),
+	})
 	if err != nil {
 		return err
 	}
@@ -157,3 +410,11 @@ func (s *TargetServiceImpl) UpdateNotes(ctx context.Context, input UpdateNotesIn
 
 	return nil
 }
+
+func normalizeCountry(name string) (string, error) {
+	code, ok := country.Normalize(name)
+	if !ok {
+		return "", errors.ErrBadRequest{Msg: fmt.Sprintf("Unknown country: %s", name)}
+	}
+	return code, nil
+}
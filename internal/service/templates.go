@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sca/internal/models"
+	"sca/internal/storage"
+	"sca/pkg/cache"
+	"sca/pkg/errors"
+	"sca/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type CreateTemplateInput struct {
+	Name     string
+	Priority int
+	Budget   money.Money
+	Targets  []CreateTemplateTargetInput
+}
+
+type CreateTemplateTargetInput struct {
+	Name           string
+	Country        string
+	Notes          string
+	RequiredSkills []string
+}
+
+type TemplateService interface {
+	Create(ctx context.Context, input CreateTemplateInput) (*models.MissionTemplate, error)
+	ById(ctx context.Context, id uuid.UUID) (*models.MissionTemplate, error)
+	All(ctx context.Context) ([]*models.MissionTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type TemplateServiceImpl struct {
+	store storage.TemplateStorage
+	cache cache.Cache
+}
+
+func NewTemplateService(store storage.TemplateStorage, cache cache.Cache) *TemplateServiceImpl {
+	return &TemplateServiceImpl{
+		store: store,
+		cache: cache,
+	}
+}
+
+func (s *TemplateServiceImpl) Create(ctx context.Context, input CreateTemplateInput) (*models.MissionTemplate, error) {
+	const cacheKey = "templates"
+
+	if len(input.Targets) == 0 || len(input.Targets) > models.MaxMissionTargets {
+		return nil, errors.ErrBadRequest{Msg: fmt.Sprintf("Cannot create template: a template must have between 1 and %d targets", models.MaxMissionTargets)}
+	}
+
+	priority := input.Priority
+	if priority == 0 {
+		priority = models.DefaultMissionPriority
+	}
+
+	budget := input.Budget
+	if budget.Currency == "" {
+		budget.Currency = money.DefaultCurrency
+	}
+
+	template := &models.MissionTemplate{
+		ID:        uuid.New(),
+		Name:      input.Name,
+		Priority:  priority,
+		Budget:    budget,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	template.Targets = make([]*models.TemplateTarget, len(input.Targets))
+	for i, t := range input.Targets {
+		countryCode, err := normalizeCountry(t.Country)
+		if err != nil {
+			return nil, err
+		}
+		template.Targets[i] = &models.TemplateTarget{
+			ID:             uuid.New(),
+			TemplateID:     template.ID,
+			Position:       i,
+			Name:           t.Name,
+			Country:        countryCode,
+			Notes:          t.Notes,
+			RequiredSkills: uniqueSkills(t.RequiredSkills),
+		}
+	}
+
+	err := s.store.Create(ctx, template)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return template, nil
+}
+
+func (s *TemplateServiceImpl) ById(ctx context.Context, id uuid.UUID) (*models.MissionTemplate, error) {
+	template, err := s.store.ById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+func (s *TemplateServiceImpl) All(ctx context.Context) ([]*models.MissionTemplate, error) {
+	const cacheKey = "templates"
+
+	return cached(ctx, s.cache, cacheKey, time.Minute*10, func() ([]*models.MissionTemplate, error) {
+		return s.store.All(ctx)
+	})
+}
+
+func (s *TemplateServiceImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	const cacheKey = "templates"
+
+	err := s.store.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_ = s.cache.Del(ctx, cacheKey)
+
+	return nil
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"sca/internal/models"
+	"sca/pkg/errors"
+	"sca/pkg/money"
+
+	"github.com/google/uuid"
+)
+
+type TransferEntity string
+
+const (
+	TransferCats     TransferEntity = "cats"
+	TransferMissions TransferEntity = "missions"
+	TransferTargets  TransferEntity = "targets"
+)
+
+type TransferFormat string
+
+const (
+	FormatCSV    TransferFormat = "csv"
+	FormatJSON   TransferFormat = "json"
+	FormatNDJSON TransferFormat = "ndjson"
+)
+
+type StructValidator interface {
+	Validate(out any) error
+}
+
+type TransferService interface {
+	Export(ctx context.Context, input ExportInput) (func(w io.Writer) error, error)
+	Import(ctx context.Context, input ImportInput) (*ImportResult, error)
+}
+
+type TransferServiceImpl struct {
+	cats      CatService
+	missions  MissionService
+	targets   TargetService
+	validator StructValidator
+}
+
+func NewTransferService(cats CatService, missions MissionService, targets TargetService, validator StructValidator) *TransferServiceImpl {
+	return &TransferServiceImpl{
+		cats:      cats,
+		missions:  missions,
+		targets:   targets,
+		validator: validator,
+	}
+}
+
+type transferRecord interface {
+	csvRow() []string
+}
+
+type catRecord struct {
+	ID                uuid.UUID   `json:"id" validate:"required"`
+	Name              string      `json:"name" validate:"required,min=3,max=32"`
+	YearsOfExperience int         `json:"years_of_experience" validate:"gte=0,lte=10"`
+	Breed             string      `json:"breed" validate:"required,breed"`
+	Status            string      `json:"status" validate:"omitempty,oneof=active on_leave retired"`
+	Salary            money.Money `json:"salary" validate:"required,gt=0,lte=10000"`
+	Skills            []string    `json:"skills" validate:"omitempty,max=20,dive,skill"`
+}
+
+type targetRecord struct {
+	ID             uuid.UUID  `json:"id" validate:"required"`
+	MissionID      *uuid.UUID `json:"mission_id,omitempty"`
+	Position       int        `json:"position"`
+	Name           string     `json:"name" validate:"required,min=3,max=32"`
+	Country        string     `json:"country" validate:"required,country"`
+	Latitude       *float64   `json:"latitude" validate:"required_with=Longitude,omitempty,latitude"`
+	Longitude      *float64   `json:"longitude" validate:"required_with=Latitude,omitempty,longitude"`
+	LastSeenAt     *time.Time `json:"last_seen_at"`
+	Notes          string     `json:"notes" validate:"required,min=3,max=255"`
+	Complete       bool       `json:"complete"`
+	RequiredSkills []string   `json:"required_skills" validate:"omitempty,max=20,dive,skill"`
+}
+
+type missionRecord struct {
+	ID       uuid.UUID       `json:"id" validate:"required"`
+	Status   string          `json:"status" validate:"omitempty,oneof=draft assigned in_progress completed aborted failed"`
+	Priority int             `json:"priority" validate:"omitempty,gte=1,lte=5"`
+	DueAt    *time.Time      `json:"due_at"`
+	Budget   money.Money     `json:"budget" validate:"gte=0"`
+	CatId    *uuid.UUID      `json:"cat_id"`
+	Targets  []*targetRecord `json:"targets,omitempty" validate:"omitempty,max=3,dive"`
+}
+
+var (
+	catColumns     = []string{"id", "name", "years_of_experience", "breed", "status", "salary", "salary_currency", "skills"}
+	missionColumns = []string{"id", "status", "priority", "due_at", "budget", "budget_currency", "cat_id"}
+	targetColumns  = []string{"id", "mission_id", "position", "name", "country", "latitude", "longitude", "last_seen_at", "notes", "complete", "required_skills"}
+)
+
+func transferColumns(entity TransferEntity) []string {
+	switch entity {
+	case TransferCats:
+		return catColumns
+	case TransferMissions:
+		return missionColumns
+	default:
+		return targetColumns
+	}
+}
+
+func newCatRecord(cat *models.Cat) *catRecord {
+	return &catRecord{
+		ID:                cat.ID,
+		Name:              cat.Name,
+		YearsOfExperience: cat.YearsOfExperience,
+		Breed:             cat.Breed,
+		Status:            string(cat.Status),
+		Salary:            cat.Salary,
+		Skills:            cat.Skills,
+	}
+}
+
+func newTargetRecord(target *models.Target) *targetRecord {
+	return &targetRecord{
+		ID:             target.ID,
+		MissionID:      target.MissionID,
+		Position:       target.Position,
+		Name:           target.Name,
+		Country:        target.Country,
+		Latitude:       target.Latitude,
+		Longitude:      target.Longitude,
+		LastSeenAt:     target.LastSeenAt,
+		Notes:          target.Notes,
+		Complete:       target.Complete,
+		RequiredSkills: target.RequiredSkills,
+	}
+}
+
+func newMissionRecord(mission *models.Mission, withTargets bool) *missionRecord {
+	record := &missionRecord{
+		ID:       mission.ID,
+		Status:   string(mission.Status),
+		Priority: mission.Priority,
+		DueAt:    mission.DueAt,
+		Budget:   mission.Budget,
+		CatId:    mission.CatId,
+	}
+	if withTargets {
+		for _, t := range mission.Targets {
+			record.Targets = append(record.Targets, newTargetRecord(t))
+		}
+	}
+	return record
+}
+
+func (r *catRecord) csvRow() []string {
+	return []string{
+		r.ID.String(),
+		r.Name,
+		strconv.Itoa(r.YearsOfExperience),
+		r.Breed,
+		r.Status,
+		r.Salary.Amount.String(),
+		r.Salary.Currency,
+		strings.Join(r.Skills, ";"),
+	}
+}
+
+func (r *missionRecord) csvRow() []string {
+	return []string{
+		r.ID.String(),
+		r.Status,
+		strconv.Itoa(r.Priority),
+		formatTime(r.DueAt),
+		r.Budget.Amount.String(),
+		r.Budget.Currency,
+		formatId(r.CatId),
+	}
+}
+
+func (r *targetRecord) csvRow() []string {
+	return []string{
+		r.ID.String(),
+		formatId(r.MissionID),
+		strconv.Itoa(r.Position),
+		r.Name,
+		r.Country,
+		formatFloat(r.Latitude),
+		formatFloat(r.Longitude),
+		formatTime(r.LastSeenAt),
+		r.Notes,
+		strconv.FormatBool(r.Complete),
+		strings.Join(r.RequiredSkills, ";"),
+	}
+}
+
+type csvRow struct {
+	columns map[string]int
+	values  []string
+	err     error
+}
+
+func (r *csvRow) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.values) {
+		return ""
+	}
+	return strings.TrimSpace(r.values[i])
+}
+
+func (r *csvRow) fail(column string, err error) {
+	if r.err == nil {
+		r.err = errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid %s: %v", column, err)}
+	}
+}
+
+func (r *csvRow) id(column string) uuid.UUID {
+	v := r.get(column)
+	if v == "" {
+		return uuid.Nil
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		r.fail(column, err)
+	}
+	return id
+}
+
+func (r *csvRow) optionalId(column string) *uuid.UUID {
+	if r.get(column) == "" {
+		return nil
+	}
+	id := r.id(column)
+	return &id
+}
+
+func (r *csvRow) int(column string) int {
+	v := r.get(column)
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		r.fail(column, err)
+	}
+	return n
+}
+
+func (r *csvRow) bool(column string) bool {
+	v := r.get(column)
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		r.fail(column, err)
+	}
+	return b
+}
+
+func (r *csvRow) float(column string) *float64 {
+	v := r.get(column)
+	if v == "" {
+		return nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		r.fail(column, err)
+		return nil
+	}
+	return &f
+}
+
+func (r *csvRow) time(column string) *time.Time {
+	v := r.get(column)
+	if v == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		r.fail(column, err)
+		return nil
+	}
+	return &t
+}
+
+func (r *csvRow) money(amountColumn, currencyColumn string) money.Money {
+	amount := r.get(amountColumn)
+	if amount == "" {
+		amount = "0"
+	}
+	m, err := money.Parse(amount, r.get(currencyColumn))
+	if err != nil {
+		r.fail(amountColumn, err)
+	}
+	return m
+}
+
+func (r *csvRow) list(column string) []string {
+	v := r.get(column)
+	if v == "" {
+		return nil
+	}
+	items := strings.Split(v, ";")
+	for i, item := range items {
+		items[i] = strings.TrimSpace(item)
+	}
+	return items
+}
+
+func (r *csvRow) catRecord() (*catRecord, error) {
+	record := &catRecord{
+		ID:                r.id("id"),
+		Name:              r.get("name"),
+		YearsOfExperience: r.int("years_of_experience"),
+		Breed:             r.get("breed"),
+		Status:            r.get("status"),
+		Salary:            r.money("salary", "salary_currency"),
+		Skills:            r.list("skills"),
+	}
+	return record, r.err
+}
+
+func (r *csvRow) missionRecord() (*missionRecord, error) {
+	record := &missionRecord{
+		ID:       r.id("id"),
+		Status:   r.get("status"),
+		Priority: r.int("priority"),
+		DueAt:    r.time("due_at"),
+		Budget:   r.money("budget", "budget_currency"),
+		CatId:    r.optionalId("cat_id"),
+	}
+	return record, r.err
+}
+
+func (r *csvRow) targetRecord() (*targetRecord, error) {
+	record := &targetRecord{
+		ID:             r.id("id"),
+		MissionID:      r.optionalId("mission_id"),
+		Position:       r.int("position"),
+		Name:           r.get("name"),
+		Country:        r.get("country"),
+		Latitude:       r.float("latitude"),
+		Longitude:      r.float("longitude"),
+		LastSeenAt:     r.time("last_seen_at"),
+		Notes:          r.get("notes"),
+		Complete:       r.bool("complete"),
+		RequiredSkills: r.list("required_skills"),
+	}
+	return record, r.err
+}
+
+func formatId(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+
+	"sca/internal/models"
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrAttachmentNotFound = errors.ErrNotFound{Msg: "Attachment not found"}
+
+type AttachmentStorage struct {
+	db *sqlx.DB
+}
+
+func NewAttachmentStorage(db *sqlx.DB) *AttachmentStorage {
+	return &AttachmentStorage{db: db}
+}
+
+func (s *AttachmentStorage) Create(ctx context.Context, attachment *models.Attachment) error {
+	query := `INSERT INTO attachments (id, owner_type, owner_id, filename, content_type, size, checksum, storage_key, uploaded_by, created_at) VALUES (:id, :owner_type, :owner_id, :filename, :content_type, :size, :checksum, :storage_key, :uploaded_by, :created_at)`
+	_, err := s.db.NamedExecContext(ctx, query, attachment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *AttachmentStorage) ById(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	query := `SELECT * FROM attachments WHERE id = ?`
+	var attachment models.Attachment
+	err := s.db.GetContext(ctx, &attachment, query, id)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (s *AttachmentStorage) ByOwner(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Attachment, error) {
+	query := `SELECT * FROM attachments WHERE owner_type = ? AND owner_id = ? ORDER BY created_at`
+	attachments := []*models.Attachment{}
+	err := s.db.SelectContext(ctx, &attachments, query, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (s *AttachmentStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM attachments WHERE id = ?`
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func deleteOwned(ctx context.Context, tx *sqlx.Tx, ownerType models.OwnerType, ownerId uuid.UUID) ([]string, error) {
+	queryKeys := `SELECT storage_key FROM attachments WHERE owner_type = ? AND owner_id = ? FOR UPDATE`
+	keys := []string{}
+	err := tx.SelectContext(ctx, &keys, queryKeys, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	queryAttachments := `DELETE FROM attachments WHERE owner_type = ? AND owner_id = ?`
+	_, err = tx.ExecContext(ctx, queryAttachments, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+
+	queryComments := `DELETE FROM comments WHERE owner_type = ? AND owner_id = ?`
+	_, err = tx.ExecContext(ctx, queryComments, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
@@ -0,0 +1,189 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"sca/pkg/errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrSchemaNotInitialised = errors.ErrConflict{Msg: "Database schema is not initialised: run the migrations first"}
+
+type BackupStorage struct {
+	db *sqlx.DB
+}
+
+func NewBackupStorage(db *sqlx.DB) *BackupStorage {
+	return &BackupStorage{db: db}
+}
+
+func (s *BackupStorage) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+	var row struct {
+		Version uint `db:"version"`
+		Dirty   bool `db:"dirty"`
+	}
+	err := s.db.GetContext(ctx, &row, query)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if stderrors.Is(err, sql.ErrNoRows) || stderrors.As(err, &mysqlErr) && mysqlErr.Number == 1146 {
+			return 0, false, ErrSchemaNotInitialised
+		}
+		return 0, false, err
+	}
+	return row.Version, row.Dirty, nil
+}
+
+func (s *BackupStorage) Counts(ctx context.Context, tables []string) (map[string]int, error) {
+	counts := make(map[string]int, len(tables))
+	for _, table := range tables {
+		var n int
+		if err := s.db.GetContext(ctx, &n, `SELECT COUNT(*) FROM `+quoteIdent(table)); err != nil {
+			return nil, err
+		}
+		counts[table] = n
+	}
+	return counts, nil
+}
+
+func (s *BackupStorage) Dump(ctx context.Context, tables []string, visit func(table string, row map[string]any) error) error {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, table := range tables {
+		if err := dumpTable(ctx, tx, table, visit); err != nil {
+			return fmt.Errorf("dump %s: %w", table, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func dumpTable(ctx context.Context, tx *sqlx.Tx, table string, visit func(table string, row map[string]any) error) error {
+	rows, err := tx.QueryxContext(ctx, `SELECT * FROM `+quoteIdent(table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		row := map[string]any{}
+		if err := rows.MapScan(row); err != nil {
+			return err
+		}
+		for column, value := range row {
+			switch v := value.(type) {
+			case []byte:
+				row[column] = string(v)
+			case time.Time:
+				row[column] = v.UTC()
+			}
+		}
+		if err := visit(table, row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *BackupStorage) Load(ctx context.Context, load func(insert func(table string, row map[string]any) error) error) (err error) {
+	conn, err := s.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `SET FOREIGN_KEY_CHECKS = 0`); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), `SET FOREIGN_KEY_CHECKS = 1`)
+	}()
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	columns := map[string]map[string]string{}
+	err = load(func(table string, row map[string]any) error {
+		types, ok := columns[table]
+		if !ok {
+			var typesErr error
+			if types, typesErr = columnTypes(ctx, tx, table); typesErr != nil {
+				return typesErr
+			}
+			columns[table] = types
+		}
+		return insertRow(ctx, tx, table, types, row)
+	})
+	return err
+}
+
+func columnTypes(ctx context.Context, tx *sqlx.Tx, table string) (map[string]string, error) {
+	rows, err := tx.QueryxContext(ctx, `SELECT * FROM `+quoteIdent(table)+` LIMIT 0`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	types := make(map[string]string, len(columns))
+	for _, c := range columns {
+		types[c.Name()] = c.DatabaseTypeName()
+	}
+	return types, nil
+}
+
+func insertRow(ctx context.Context, tx *sqlx.Tx, table string, types map[string]string, row map[string]any) error {
+	names := make([]string, 0, len(row))
+	args := make([]any, 0, len(row))
+	for column, value := range row {
+		typ, ok := types[column]
+		if !ok {
+			return errors.ErrBadRequest{Msg: fmt.Sprintf("Unknown column %s.%s", table, column)}
+		}
+		switch typ {
+		case "DATE", "DATETIME", "TIMESTAMP":
+			if v, ok := value.(string); ok {
+				t, err := time.Parse(time.RFC3339Nano, v)
+				if err != nil {
+					return errors.ErrBadRequest{Msg: fmt.Sprintf("Invalid %s.%s: %v", table, column, err)}
+				}
+				value = t.UTC()
+			}
+		}
+		names = append(names, quoteIdent(column))
+		args = append(args, value)
+	}
+	if len(names) == 0 {
+		return errors.ErrBadRequest{Msg: fmt.Sprintf("Empty row in %s", table)}
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (?%s)`, quoteIdent(table), strings.Join(names, ", "), strings.Repeat(", ?", len(names)-1))
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
+
+func quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
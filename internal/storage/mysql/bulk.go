@@ -0,0 +1,41 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func insertBulk(ctx context.Context, db *sqlx.DB, n int, atomic bool, insert func(tx *sqlx.Tx, i int) error) ([]error, error) {
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := make([]error, n)
+	for i := range n {
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, `SAVEPOINT bulk_item`); err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+
+		if err := insert(tx, i); err != nil {
+			errs[i] = err
+			if atomic {
+				_ = tx.Rollback()
+				return errs, nil
+			}
+			if _, err := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT bulk_item`); err != nil {
+				_ = tx.Rollback()
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
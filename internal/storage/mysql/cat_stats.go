@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+
+	"sca/internal/models"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const catStatsTimed = `m.status = :completed AND tr.started_at IS NOT NULL AND tr.completed_at IS NOT NULL AND tc.targets > 0`
+
+const catStatsQuery = `SELECT c.id AS cat_id, c.name,
+		COALESCE(ms.missions_completed, 0) AS missions_completed,
+		COALESCE(ms.missions_aborted, 0) AS missions_aborted,
+		COALESCE(ts.targets_completed, 0) AS targets_completed,
+		ms.target_seconds / NULLIF(ms.timed_targets, 0) AS avg_target_seconds,
+		COALESCE(ts.countries_count, 0) AS countries_count,
+		ms.on_time / NULLIF(ms.due_missions, 0) AS on_time_rate
+	FROM cats c
+	LEFT JOIN (
+		SELECT m.cat_id,
+			SUM(m.status = :completed) AS missions_completed,
+			SUM(m.status = :aborted) AS missions_aborted,
+			SUM(CASE WHEN ` + catStatsTimed + ` THEN TIMESTAMPDIFF(SECOND, tr.started_at, tr.completed_at) END) AS target_seconds,
+			SUM(CASE WHEN ` + catStatsTimed + ` THEN tc.targets END) AS timed_targets,
+			SUM(m.status = :completed AND m.due_at IS NOT NULL AND tr.completed_at <= m.due_at) AS on_time,
+			SUM(m.status = :completed AND m.due_at IS NOT NULL AND tr.completed_at IS NOT NULL) AS due_missions
+		FROM missions m
+		LEFT JOIN (
+			SELECT mt.mission_id,
+				MIN(CASE WHEN mt.to_status = :in_progress THEN mt.created_at END) AS started_at,
+				MAX(CASE WHEN mt.to_status = :completed THEN mt.created_at END) AS completed_at
+			FROM mission_transitions mt
+			JOIN missions m ON m.id = mt.mission_id
+			WHERE m.cat_id IS NOT NULL %[1]s
+			GROUP BY mt.mission_id
+		) tr ON tr.mission_id = m.id
+		LEFT JOIN (
+			SELECT t.mission_id, COUNT(*) AS targets
+			FROM targets t
+			JOIN missions m ON m.id = t.mission_id
+			WHERE m.cat_id IS NOT NULL %[1]s
+			GROUP BY t.mission_id
+		) tc ON tc.mission_id = m.id
+		WHERE m.cat_id IS NOT NULL %[1]s
+		GROUP BY m.cat_id
+	) ms ON ms.cat_id = c.id
+	LEFT JOIN (
+		SELECT m.cat_id, COUNT(*) AS targets_completed, COUNT(DISTINCT t.country) AS countries_count
+		FROM targets t
+		JOIN missions m ON m.id = t.mission_id
+		WHERE m.cat_id IS NOT NULL AND t.complete %[1]s
+		GROUP BY m.cat_id
+	) ts ON ts.cat_id = c.id`
+
+var catStatsSortColumns = map[string]string{
+	"missions_completed": "missions_completed DESC",
+	"targets_completed":  "targets_completed DESC",
+	"countries":          "countries_count DESC",
+	"on_time_rate":       "on_time_rate IS NULL, on_time_rate DESC",
+	"avg_target_time":    "avg_target_seconds IS NULL, avg_target_seconds ASC",
+}
+
+func catStatsArgs(args map[string]any) map[string]any {
+	args["completed"] = models.MissionCompleted
+	args["aborted"] = models.MissionAborted
+	args["in_progress"] = models.MissionInProgress
+	return args
+}
+
+func (s *CatStorage) Stats(ctx context.Context, catId uuid.UUID) (*models.CatStats, error) {
+	query, args, err := sqlx.Named(fmt.Sprintf(catStatsQuery, "AND m.cat_id = :cat_id")+` WHERE c.id = :cat_id`, catStatsArgs(map[string]any{"cat_id": catId}))
+	if err != nil {
+		return nil, err
+	}
+
+	var stats models.CatStats
+	err = s.db.GetContext(ctx, &stats, s.db.Rebind(query), args...)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCatNotFound
+		}
+		return nil, err
+	}
+
+	countriesQuery := `SELECT DISTINCT t.country
+		FROM targets t
+		JOIN missions m ON m.id = t.mission_id
+		WHERE m.cat_id = ? AND t.complete
+		ORDER BY t.country`
+	stats.Countries = []string{}
+	err = s.db.SelectContext(ctx, &stats.Countries, countriesQuery, catId)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+func (s *CatStorage) Leaderboard(ctx context.Context, filter models.CatLeaderboardFilter) ([]*models.CatStats, error) {
+	order, ok := catStatsSortColumns[filter.SortBy]
+	if !ok {
+		order = catStatsSortColumns["missions_completed"]
+	}
+
+	query, args, err := sqlx.Named(fmt.Sprintf(catStatsQuery, "")+` ORDER BY `+order+`, c.name LIMIT :limit`, catStatsArgs(map[string]any{"limit": filter.Limit}))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := []*models.CatStats{}
+	err = s.db.SelectContext(ctx, &stats, s.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
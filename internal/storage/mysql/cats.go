@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	stderrors "errors"
+	"time"
 
 	"sca/internal/models"
 	"sca/pkg/database/mysql"
@@ -14,8 +15,17 @@ import (
 )
 
 var (
-	ErrCatAlreadyExists = errors.ErrConflict{Msg: "Cat is already exists"}
-	ErrCatNotFound      = errors.ErrNotFound{Msg: "Cat not found"}
+	ErrCatAlreadyExists     = errors.ErrConflict{Msg: "Cat is already exists"}
+	ErrCatNotFound          = errors.ErrNotFound{Msg: "Cat not found"}
+	ErrLeaveNotFound        = errors.ErrNotFound{Msg: "Leave not found"}
+	ErrCatHasActiveMissions = errors.ErrConflict{Msg: "Cannot delete cat: it is assigned to missions that are not finished, unassign or reassign them first"}
+)
+
+const (
+	catColumns = "c.id, c.name, c.years_of_experience, c.breed, c.status, c.retired_at, " +
+		"COALESCE((SELECT sc.salary FROM cat_salary_changes sc WHERE sc.cat_id = c.id AND sc.effective_from <= UTC_DATE() ORDER BY sc.effective_from DESC, sc.created_at DESC LIMIT 1), c.salary) AS `salary.amount`, " +
+		"COALESCE((SELECT sc.currency FROM cat_salary_changes sc WHERE sc.cat_id = c.id AND sc.effective_from <= UTC_DATE() ORDER BY sc.effective_from DESC, sc.created_at DESC LIMIT 1), c.salary_currency) AS `salary.currency`"
+	salaryChangeColumns = "id, cat_id, salary AS `salary.amount`, currency AS `salary.currency`, effective_from, approved_by, created_at"
 )
 
 type CatStorage struct {
@@ -27,19 +37,34 @@ func NewCatStorage(db *sqlx.DB) *CatStorage {
 }
 
 func (s *CatStorage) Create(ctx context.Context, cat *models.Cat) error {
-	query := `INSERT INTO cats (id, name, years_of_experience, breed, salary) VALUES (:id, :name, :years_of_experience, :breed, :salary)`
-	_, err := s.db.NamedExecContext(ctx, query, cat)
+	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
-		if mysql.IsDuplicate(err) {
-			return ErrCatAlreadyExists
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
 		}
+	}()
+
+	err = insertCat(ctx, tx, cat)
+	if err != nil {
 		return err
 	}
+
 	return nil
 }
 
+func (s *CatStorage) CreateMany(ctx context.Context, cats []*models.Cat, atomic bool) ([]error, error) {
+	return insertBulk(ctx, s.db, len(cats), atomic, func(tx *sqlx.Tx, i int) error {
+		return insertCat(ctx, tx, cats[i])
+	})
+}
+
 func (s *CatStorage) ById(ctx context.Context, id uuid.UUID) (*models.Cat, error) {
-	query := `SELECT * FROM cats WHERE id = ?`
+	query := `SELECT ` + catColumns + ` FROM cats c WHERE c.id = ?`
 	var cat models.Cat
 	err := s.db.GetContext(ctx, &cat, query, id)
 	if err != nil {
@@ -48,13 +73,42 @@ func (s *CatStorage) ById(ctx context.Context, id uuid.UUID) (*models.Cat, error
 		}
 		return nil, err
 	}
+
+	leaves, err := s.Leaves(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cat.Leaves = leaves
+
+	err = loadCatSkills(ctx, s.db, []*models.Cat{&cat})
+	if err != nil {
+		return nil, err
+	}
+
 	return &cat, nil
 }
 
-func (s *CatStorage) All(ctx context.Context) ([]*models.Cat, error) {
-	query := `SELECT * FROM cats`
+func (s *CatStorage) All(ctx context.Context, filter models.CatFilter) ([]*models.Cat, error) {
+	query := `SELECT ` + catColumns + ` FROM cats c`
+	var args []any
+	if len(filter.Skills) > 0 {
+		var err error
+		query, args, err = sqlx.In(query+` WHERE c.id IN (
+			SELECT cat_id FROM cat_skills WHERE skill IN (?) GROUP BY cat_id HAVING COUNT(*) = ?
+		)`, filter.Skills, len(filter.Skills))
+		if err != nil {
+			return nil, err
+		}
+		query = s.db.Rebind(query)
+	}
+
 	cats := []*models.Cat{}
-	err := s.db.SelectContext(ctx, &cats, query)
+	err := s.db.SelectContext(ctx, &cats, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadCatSkills(ctx, s.db, cats)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +116,7 @@ func (s *CatStorage) All(ctx context.Context) ([]*models.Cat, error) {
 }
 
 func (s *CatStorage) Update(ctx context.Context, cat *models.Cat) error {
-	query := `UPDATE cats SET salary = :salary WHERE id = :id`
+	query := `UPDATE cats SET salary = :salary.amount, salary_currency = :salary.currency, status = :status, retired_at = :retired_at WHERE id = :id`
 	_, err := s.db.NamedExecContext(ctx, query, cat)
 	if err != nil {
 		return err
@@ -70,10 +124,301 @@ func (s *CatStorage) Update(ctx context.Context, cat *models.Cat) error {
 	return nil
 }
 
-func (s *CatStorage) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM cats WHERE id = ?`
-	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+func (s *CatStorage) Delete(ctx context.Context, id uuid.UUID) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	queryCat := `SELECT id FROM cats WHERE id = ? FOR UPDATE`
+	var catId uuid.UUID
+	err = tx.GetContext(ctx, &catId, queryCat, id)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			err = ErrCatNotFound
+		}
 		return err
 	}
+
+	queryMissions := `SELECT id FROM missions WHERE cat_id = ? AND status IN (?, ?) FOR UPDATE`
+	var active []uuid.UUID
+	err = tx.SelectContext(ctx, &active, queryMissions, id, models.MissionAssigned, models.MissionInProgress)
+	if err != nil {
+		return err
+	}
+	if len(active) > 0 {
+		err = ErrCatHasActiveMissions
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM cats WHERE id = ?`, id)
+	return err
+}
+
+func (s *CatStorage) Available(ctx context.Context, from, to time.Time) ([]*models.Cat, error) {
+	query := `SELECT ` + catColumns + ` FROM cats c
+		WHERE c.status = ?
+		  AND NOT EXISTS (SELECT 1 FROM cat_leaves l WHERE l.cat_id = c.id AND l.starts_at <= ? AND l.ends_at > ?)`
+	cats := []*models.Cat{}
+	err := s.db.SelectContext(ctx, &cats, query, models.CatActive, to, from)
+	if err != nil {
+		return nil, err
+	}
+
+	err = loadCatSkills(ctx, s.db, cats)
+	if err != nil {
+		return nil, err
+	}
+	return cats, nil
+}
+
+func (s *CatStorage) AddLeave(ctx context.Context, leave *models.CatLeave) error {
+	query := `INSERT INTO cat_leaves (id, cat_id, starts_at, ends_at, reason) VALUES (:id, :cat_id, :starts_at, :ends_at, :reason)`
+	_, err := s.db.NamedExecContext(ctx, query, leave)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *CatStorage) Leaves(ctx context.Context, catId uuid.UUID) ([]*models.CatLeave, error) {
+	query := `SELECT * FROM cat_leaves WHERE cat_id = ? ORDER BY starts_at`
+	leaves := []*models.CatLeave{}
+	err := s.db.SelectContext(ctx, &leaves, query, catId)
+	if err != nil {
+		return nil, err
+	}
+	return leaves, nil
+}
+
+func (s *CatStorage) DeleteLeave(ctx context.Context, catId, leaveId uuid.UUID) error {
+	query := `DELETE FROM cat_leaves WHERE id = ? AND cat_id = ?`
+	res, err := s.db.ExecContext(ctx, query, leaveId, catId)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrLeaveNotFound
+	}
+	return nil
+}
+
+func (s *CatStorage) UpdateSalary(ctx context.Context, change *models.SalaryChange) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = insertSalaryChange(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+
+	if change.EffectiveFrom.After(time.Now().UTC()) {
+		return nil
+	}
+
+	query := `UPDATE cats SET salary = ?, salary_currency = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, query, change.Salary.Amount, change.Salary.Currency, change.CatID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *CatStorage) SalaryChanges(ctx context.Context, catId uuid.UUID) ([]*models.SalaryChange, error) {
+	query := `SELECT ` + salaryChangeColumns + ` FROM cat_salary_changes WHERE cat_id = ? ORDER BY effective_from, created_at`
+	changes := []*models.SalaryChange{}
+	err := s.db.SelectContext(ctx, &changes, query, catId)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (s *CatStorage) SalaryChangesUntil(ctx context.Context, until time.Time) ([]*models.SalaryChange, error) {
+	query := `SELECT ` + salaryChangeColumns + ` FROM cat_salary_changes WHERE effective_from < ? ORDER BY cat_id, effective_from, created_at`
+	changes := []*models.SalaryChange{}
+	err := s.db.SelectContext(ctx, &changes, query, until)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (s *CatStorage) SalaryChangesByCats(ctx context.Context, catIds []uuid.UUID) ([]*models.SalaryChange, error) {
+	changes := []*models.SalaryChange{}
+	if len(catIds) == 0 {
+		return changes, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT `+salaryChangeColumns+` FROM cat_salary_changes WHERE cat_id IN (?) ORDER BY cat_id, effective_from, created_at`, catIds)
+	if err != nil {
+		return nil, err
+	}
+	err = s.db.SelectContext(ctx, &changes, s.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (s *CatStorage) Workloads(ctx context.Context) ([]*models.CatWorkload, error) {
+	query := `SELECT cat_id, COUNT(*) AS active FROM missions
+		WHERE cat_id IS NOT NULL AND status IN (?, ?)
+		GROUP BY cat_id`
+	workloads := []*models.CatWorkload{}
+	err := s.db.SelectContext(ctx, &workloads, query, models.MissionAssigned, models.MissionInProgress)
+	if err != nil {
+		return nil, err
+	}
+	return workloads, nil
+}
+
+func (s *CatStorage) CountryRecords(ctx context.Context, countries []string) ([]*models.CatCountryRecord, error) {
+	records := []*models.CatCountryRecord{}
+	if len(countries) == 0 {
+		return records, nil
+	}
+
+	query, args, err := sqlx.In(`SELECT m.cat_id, t.country, SUM(t.complete) AS completed, COUNT(*) AS total
+		FROM targets t
+		JOIN missions m ON m.id = t.mission_id
+		WHERE m.cat_id IS NOT NULL
+		  AND m.status IN (?, ?, ?)
+		  AND t.country IN (?)
+		GROUP BY m.cat_id, t.country`, models.MissionCompleted, models.MissionAborted, models.MissionFailed, countries)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.SelectContext(ctx, &records, s.db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (s *CatStorage) SetSkills(ctx context.Context, catId uuid.UUID, skills []string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `DELETE FROM cat_skills WHERE cat_id = ?`
+	_, err = tx.ExecContext(ctx, query, catId)
+	if err != nil {
+		return err
+	}
+
+	err = insertCatSkills(ctx, tx, catId, skills)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func insertSalaryChange(ctx context.Context, tx *sqlx.Tx, change *models.SalaryChange) error {
+	query := `INSERT INTO cat_salary_changes (id, cat_id, salary, currency, effective_from, approved_by, created_at) VALUES (:id, :cat_id, :salary.amount, :salary.currency, :effective_from, :approved_by, :created_at)`
+	_, err := tx.NamedExecContext(ctx, query, change)
+	return err
+}
+
+func insertCat(ctx context.Context, tx *sqlx.Tx, cat *models.Cat) error {
+	query := `INSERT INTO cats (id, name, years_of_experience, breed, status, retired_at, salary, salary_currency) VALUES (:id, :name, :years_of_experience, :breed, :status, :retired_at, :salary.amount, :salary.currency)`
+	_, err := tx.NamedExecContext(ctx, query, cat)
+	if err != nil {
+		if mysql.IsDuplicate(err) {
+			return ErrCatAlreadyExists
+		}
+		return err
+	}
+
+	now := time.Now().UTC()
+	err = insertSalaryChange(ctx, tx, &models.SalaryChange{
+		ID:            uuid.New(),
+		CatID:         cat.ID,
+		Salary:        cat.Salary,
+		EffectiveFrom: now.Truncate(24 * time.Hour),
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = insertCatSkills(ctx, tx, cat.ID, cat.Skills)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func insertCatSkills(ctx context.Context, tx *sqlx.Tx, catId uuid.UUID, skills []string) error {
+	query := `INSERT INTO cat_skills (cat_id, skill) VALUES (?, ?)`
+	for _, skill := range skills {
+		if _, err := tx.ExecContext(ctx, query, catId, skill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadCatSkills(ctx context.Context, db *sqlx.DB, cats []*models.Cat) error {
+	if len(cats) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(cats))
+	byId := make(map[uuid.UUID]*models.Cat, len(cats))
+	for i, c := range cats {
+		ids[i] = c.ID
+		byId[c.ID] = c
+		c.Skills = []string{}
+	}
+
+	query, args, err := sqlx.In(`SELECT cat_id, skill FROM cat_skills WHERE cat_id IN (?) ORDER BY skill`, ids)
+	if err != nil {
+		return err
+	}
+
+	var rows []struct {
+		CatID uuid.UUID `db:"cat_id"`
+		Skill string    `db:"skill"`
+	}
+	err = db.SelectContext(ctx, &rows, db.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	for _, r := range rows {
+		byId[r.CatID].Skills = append(byId[r.CatID].Skills, r.Skill)
+	}
 	return nil
 }
@@ -0,0 +1,83 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+
+	"sca/internal/models"
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrCommentNotFound = errors.ErrNotFound{Msg: "Comment not found"}
+
+type CommentStorage struct {
+	db *sqlx.DB
+}
+
+func NewCommentStorage(db *sqlx.DB) *CommentStorage {
+	return &CommentStorage{db: db}
+}
+
+func (s *CommentStorage) Create(ctx context.Context, comment *models.Comment) error {
+	query := `INSERT INTO comments (id, owner_type, owner_id, parent_id, author, body, mentions, created_at) VALUES (:id, :owner_type, :owner_id, :parent_id, :author, :body, :mentions, :created_at)`
+	_, err := s.db.NamedExecContext(ctx, query, comment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *CommentStorage) ById(ctx context.Context, id uuid.UUID) (*models.Comment, error) {
+	query := `SELECT * FROM comments WHERE id = ?`
+	var comment models.Comment
+	err := s.db.GetContext(ctx, &comment, query, id)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (s *CommentStorage) ByOwner(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Comment, error) {
+	query := `SELECT * FROM comments WHERE owner_type = ? AND owner_id = ? ORDER BY created_at, id`
+	comments := []*models.Comment{}
+	err := s.db.SelectContext(ctx, &comments, query, ownerType, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (s *CommentStorage) Update(ctx context.Context, comment *models.Comment) error {
+	query := `UPDATE comments SET body = :body, mentions = :mentions, edited_at = :edited_at WHERE id = :id`
+	_, err := s.db.NamedExecContext(ctx, query, comment)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *CommentStorage) CountReplies(ctx context.Context, id uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM comments WHERE parent_id = ?`
+	var count int
+	err := s.db.GetContext(ctx, &count, query, id)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *CommentStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM comments WHERE id = ?`
+	_, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
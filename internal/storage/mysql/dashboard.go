@@ -0,0 +1,99 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"sca/internal/models"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type DashboardStorage struct {
+	db *sqlx.DB
+}
+
+func NewDashboardStorage(db *sqlx.DB) *DashboardStorage {
+	return &DashboardStorage{db: db}
+}
+
+func (s *DashboardStorage) CatAvailability(ctx context.Context, now time.Time) (*models.CatAvailability, error) {
+	query := `SELECT COUNT(*) AS total,
+			COALESCE(SUM(c.status = ? AND l.cat_id IS NULL AND w.cat_id IS NULL), 0) AS available,
+			COALESCE(SUM(c.status = ? AND l.cat_id IS NULL AND w.cat_id IS NOT NULL), 0) AS on_mission,
+			COALESCE(SUM(c.status = ? OR (c.status = ? AND l.cat_id IS NOT NULL)), 0) AS on_leave,
+			COALESCE(SUM(c.status = ?), 0) AS retired
+		FROM cats c
+		LEFT JOIN (
+			SELECT DISTINCT cat_id FROM cat_leaves WHERE starts_at <= ? AND ends_at > ?
+		) l ON l.cat_id = c.id
+		LEFT JOIN (
+			SELECT DISTINCT cat_id FROM missions WHERE cat_id IS NOT NULL AND status IN (?, ?)
+		) w ON w.cat_id = c.id`
+	var availability models.CatAvailability
+	err := s.db.GetContext(ctx, &availability, query,
+		models.CatActive, models.CatActive, models.CatOnLeave, models.CatActive, models.CatRetired,
+		now, now, models.MissionAssigned, models.MissionInProgress)
+	if err != nil {
+		return nil, err
+	}
+	return &availability, nil
+}
+
+func (s *DashboardStorage) MissionCounts(ctx context.Context) ([]*models.MissionStatusCount, error) {
+	query := `SELECT status, COUNT(*) AS count FROM missions GROUP BY status`
+	counts := []*models.MissionStatusCount{}
+	err := s.db.SelectContext(ctx, &counts, query)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (s *DashboardStorage) OpenTargets(ctx context.Context) ([]*models.CountryCount, error) {
+	query := `SELECT t.country, COUNT(*) AS count
+		FROM targets t
+		LEFT JOIN missions m ON m.id = t.mission_id
+		WHERE NOT t.complete AND (m.id IS NULL OR m.status NOT IN (?, ?, ?))
+		GROUP BY t.country
+		ORDER BY count DESC, t.country`
+	counts := []*models.CountryCount{}
+	err := s.db.SelectContext(ctx, &counts, query, models.MissionCompleted, models.MissionAborted, models.MissionFailed)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (s *DashboardStorage) Unassigned(ctx context.Context, now time.Time) (*models.UnassignedMissions, error) {
+	query := `SELECT COUNT(*) AS count,
+			COALESCE(SUM(due_at < ?), 0) AS overdue,
+			MIN(CASE WHEN due_at >= ? THEN due_at END) AS next_due_at
+		FROM missions
+		WHERE cat_id IS NULL AND status = ?`
+	var unassigned models.UnassignedMissions
+	err := s.db.GetContext(ctx, &unassigned, query, now, now, models.MissionDraft)
+	if err != nil {
+		return nil, err
+	}
+	return &unassigned, nil
+}
+
+func (s *DashboardStorage) CompletionTrends(ctx context.Context, since time.Time) ([]*models.CompletionTrend, error) {
+	query := `SELECT DATE(created_at) AS day,
+			SUM(to_status = ?) AS completed,
+			SUM(to_status = ?) AS aborted,
+			SUM(to_status = ?) AS failed
+		FROM mission_transitions
+		WHERE to_status IN (?, ?, ?) AND created_at >= ?
+		GROUP BY day
+		ORDER BY day`
+	trends := []*models.CompletionTrend{}
+	err := s.db.SelectContext(ctx, &trends, query,
+		models.MissionCompleted, models.MissionAborted, models.MissionFailed,
+		models.MissionCompleted, models.MissionAborted, models.MissionFailed, since)
+	if err != nil {
+		return nil, err
+	}
+	return trends, nil
+}
@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+
+	"sca/internal/models"
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var ErrDebriefNotFound = errors.ErrNotFound{Msg: "Mission debrief not found"}
+
+type DebriefStorage struct {
+	db *sqlx.DB
+}
+
+func NewDebriefStorage(db *sqlx.DB) *DebriefStorage {
+	return &DebriefStorage{db: db}
+}
+
+func (s *DebriefStorage) Save(ctx context.Context, debrief *models.MissionDebrief, completeMission bool) (completed bool, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	queryMission := `SELECT status FROM missions WHERE id = ? FOR UPDATE`
+	var status models.MissionStatus
+	err = tx.GetContext(ctx, &status, queryMission, debrief.MissionID)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			err = ErrMissionNotFound
+		}
+		return false, err
+	}
+
+	queryExists := `SELECT EXISTS(SELECT 1 FROM mission_debriefs WHERE mission_id = ? FOR UPDATE)`
+	var exists bool
+	err = tx.GetContext(ctx, &exists, queryExists, debrief.MissionID)
+	if err != nil {
+		return false, err
+	}
+	if exists && status.IsFinal() {
+		err = errors.ErrConflict{Msg: fmt.Sprintf("Cannot update debrief: mission is %s", status)}
+		return false, err
+	}
+
+	queryDebrief := `INSERT INTO mission_debriefs (mission_id, cat_id, cat_rating, lessons_learned, author, created_at) VALUES (:mission_id, :cat_id, :cat_rating, :lessons_learned, :author, :created_at)
+		ON DUPLICATE KEY UPDATE cat_id = VALUES(cat_id), cat_rating = VALUES(cat_rating), lessons_learned = VALUES(lessons_learned), author = VALUES(author), updated_at = VALUES(created_at)`
+	_, err = tx.NamedExecContext(ctx, queryDebrief, debrief)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM mission_debrief_outcomes WHERE mission_id = ?`, debrief.MissionID)
+	if err != nil {
+		return false, err
+	}
+
+	queryOutcome := `INSERT INTO mission_debrief_outcomes (mission_id, target_id, outcome, notes) VALUES (:mission_id, :target_id, :outcome, :notes)`
+	for _, o := range debrief.Outcomes {
+		_, err = tx.NamedExecContext(ctx, queryOutcome, o)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if !completeMission {
+		return false, nil
+	}
+	completed, err = completeMissionIfDone(ctx, tx, debrief.MissionID, true)
+	return completed, err
+}
+
+func (s *DebriefStorage) ByMission(ctx context.Context, missionId uuid.UUID) (*models.MissionDebrief, error) {
+	query := `SELECT * FROM mission_debriefs WHERE mission_id = ?`
+	var debrief models.MissionDebrief
+	err := s.db.GetContext(ctx, &debrief, query, missionId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrDebriefNotFound
+		}
+		return nil, err
+	}
+
+	queryOutcomes := `SELECT o.* FROM mission_debrief_outcomes o JOIN targets t ON t.id = o.target_id WHERE o.mission_id = ? ORDER BY t.position`
+	outcomes := []*models.DebriefOutcome{}
+	err = s.db.SelectContext(ctx, &outcomes, queryOutcomes, missionId)
+	if err != nil {
+		return nil, err
+	}
+	debrief.Outcomes = outcomes
+
+	return &debrief, nil
+}
+
+func (s *DebriefStorage) Exists(ctx context.Context, missionId uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM mission_debriefs WHERE mission_id = ?)`
+	var exists bool
+	err := s.db.GetContext(ctx, &exists, query, missionId)
+	if err != nil {
+		return false, err
+	}
+	return exists, nil
+}
@@ -0,0 +1,38 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type LockStorage struct {
+	db *sqlx.DB
+}
+
+func NewLockStorage(db *sqlx.DB) *LockStorage {
+	return &LockStorage{db: db}
+}
+
+func (s *LockStorage) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := s.db.Connx(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired *int
+	if err := conn.GetContext(ctx, &acquired, `SELECT GET_LOCK(?, 0)`, name); err != nil {
+		_ = conn.Close()
+		return nil, false, err
+	}
+	if acquired == nil || *acquired != 1 {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		_, _ = conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, name)
+		_ = conn.Close()
+	}
+	return unlock, true, nil
+}
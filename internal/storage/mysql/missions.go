@@ -4,6 +4,10 @@ import (
 	"context"
 	"database/sql"
 	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"sca/internal/models"
 	"sca/pkg/errors"
@@ -12,7 +16,21 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-var ErrMissionNotFound = errors.ErrNotFound{Msg: "Mission not found"}
+var (
+	ErrMissionNotFound      = errors.ErrNotFound{Msg: "Mission not found"}
+	ErrExpenseNotFound      = errors.ErrNotFound{Msg: "Expense not found"}
+	ErrMissionStatusChanged = errors.ErrConflict{Msg: "Mission status has changed, please retry"}
+	ErrMissionCatChanged    = errors.ErrConflict{Msg: "Mission cat has changed, please retry"}
+	ErrTargetMissionChanged = errors.ErrConflict{Msg: "Target mission has changed, please retry"}
+	ErrMissionTargetsFull   = errors.ErrConflict{Msg: fmt.Sprintf("Mission already has %d targets", models.MaxMissionTargets)}
+	ErrMissionLastTarget    = errors.ErrConflict{Msg: "Cannot remove the last target of a mission"}
+)
+
+const (
+	missionColumns = "m.id, m.status, m.priority, m.due_at, m.overdue_at, m.budget AS `budget.amount`, m.budget_currency AS `budget.currency`, m.cat_id"
+	missionUrgency = "m.priority DESC, m.due_at IS NULL, m.due_at, m.id"
+	expenseColumns = "id, mission_id, description, amount AS `amount.amount`, currency AS `amount.currency`, incurred_at, created_at"
+)
 
 type MissionStorage struct {
 	db *sqlx.DB
@@ -35,25 +53,55 @@ func (s *MissionStorage) Create(ctx context.Context, mission *models.Mission, ta
 		}
 	}()
 
-	queryMission := `INSERT INTO missions (id, cat_id, complete) VALUES (:id, :cat_id, :complete)`
+	queryMission := `INSERT INTO missions (id, status, priority, due_at, budget, budget_currency, cat_id) VALUES (:id, :status, :priority, :due_at, :budget.amount, :budget.currency, :cat_id)`
 	_, err = tx.NamedExecContext(ctx, queryMission, mission)
 	if err != nil {
 		return err
 	}
 
-	queryTarget := `INSERT INTO targets (id, name, country, notes, complete, mission_id) VALUES (:id, :name, :country, :notes, :complete, :mission_id)`
+	queryTransition := `INSERT INTO mission_transitions (id, mission_id, from_status, to_status, reason, created_at) VALUES (?, ?, NULL, ?, NULL, ?)`
+	_, err = tx.ExecContext(ctx, queryTransition, uuid.New(), mission.ID, mission.Status, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if mission.CatId != nil {
+		queryAssignment := `INSERT INTO mission_assignments (id, mission_id, from_cat_id, to_cat_id, reason, created_at) VALUES (?, ?, NULL, ?, NULL, ?)`
+		_, err = tx.ExecContext(ctx, queryAssignment, uuid.New(), mission.ID, mission.CatId, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+	}
+
+	queryTarget := `INSERT INTO targets (id, name, country, latitude, longitude, last_seen_at, notes, complete, mission_id, position) VALUES (:id, :name, :country, :latitude, :longitude, :last_seen_at, :notes, :complete, :mission_id, :position)`
 	for _, t := range targets {
 		_, err = tx.NamedExecContext(ctx, queryTarget, t)
 		if err != nil {
 			return err
 		}
+
+		err = insertNoteRevision(ctx, tx, &models.NoteRevision{
+			ID:        uuid.New(),
+			TargetID:  t.ID,
+			Version:   1,
+			Notes:     t.Notes,
+			CreatedAt: time.Now().UTC(),
+		})
+		if err != nil {
+			return err
+		}
+
+		err = insertTargetSkills(ctx, tx, t.ID, t.RequiredSkills)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
 func (s *MissionStorage) ById(ctx context.Context, id uuid.UUID) (*models.Mission, error) {
-	query := `SELECT * FROM missions WHERE id = ?`
+	query := `SELECT ` + missionColumns + ` FROM missions m WHERE m.id = ?`
 	var mission models.Mission
 	err := s.db.GetContext(ctx, &mission, query, id)
 	if err != nil {
@@ -63,40 +111,99 @@ func (s *MissionStorage) ById(ctx context.Context, id uuid.UUID) (*models.Missio
 		return nil, err
 	}
 
-	targetsQuery := `SELECT * FROM targets WHERE mission_id = ?`
+	targetsQuery := `SELECT * FROM targets WHERE mission_id = ? ORDER BY position`
 	var targets []*models.Target
 	err = s.db.SelectContext(ctx, &targets, targetsQuery, mission.ID)
 	if err != nil {
 		return nil, err
 	}
+	err = loadTargetSkills(ctx, s.db, targets)
+	if err != nil {
+		return nil, err
+	}
 	mission.Targets = targets
 
 	return &mission, nil
 }
 
-func (s *MissionStorage) All(ctx context.Context) ([]*models.Mission, error) {
-	query := `SELECT * FROM missions`
+var missionSortColumns = map[string]string{
+	"priority": "priority",
+	"due_at":   "due_at",
+}
+
+func (s *MissionStorage) All(ctx context.Context, filter models.MissionFilter) ([]*models.Mission, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.Priority != 0 {
+		conditions = append(conditions, "priority = ?")
+		args = append(args, filter.Priority)
+	}
+	if filter.Overdue != nil {
+		if *filter.Overdue {
+			conditions = append(conditions, "overdue_at IS NOT NULL")
+		} else {
+			conditions = append(conditions, "overdue_at IS NULL")
+		}
+	}
+	if !filter.DueBefore.IsZero() {
+		conditions = append(conditions, "due_at < ?")
+		args = append(args, filter.DueBefore)
+	}
+	if !filter.DueAfter.IsZero() {
+		conditions = append(conditions, "due_at > ?")
+		args = append(args, filter.DueAfter)
+	}
+
+	query := `SELECT ` + missionColumns + ` FROM missions m`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	if filter.SortBy == "priority" && filter.Order == "" {
+		query += ` ORDER BY ` + missionUrgency
+	} else if column, ok := missionSortColumns[filter.SortBy]; ok {
+		direction := "ASC"
+		if filter.Order == "desc" {
+			direction = "DESC"
+		}
+		query += fmt.Sprintf(` ORDER BY %s IS NULL, %s %s, id`, column, column, direction)
+	}
+
 	missions := []*models.Mission{}
-	err := s.db.SelectContext(ctx, &missions, query)
+	err := s.db.SelectContext(ctx, &missions, query, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, mission := range missions {
-		targetsQuery := `SELECT * FROM targets WHERE mission_id = ?`
-		var targets []*models.Target
-		err = s.db.SelectContext(ctx, &targets, targetsQuery, mission.ID)
-		if err != nil {
-			return nil, err
-		}
-		mission.Targets = targets
+	ids := make([]uuid.UUID, len(missions))
+	byId := make(map[uuid.UUID]*models.Mission, len(missions))
+	for i, mission := range missions {
+		ids[i] = mission.ID
+		byId[mission.ID] = mission
+	}
+	targets, err := selectByMissions[*models.Target](ctx, s.db, `SELECT * FROM targets WHERE mission_id IN (?) ORDER BY position`, ids)
+	if err != nil {
+		return nil, err
+	}
+	err = loadTargetSkills(ctx, s.db, targets)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range targets {
+		mission := byId[*t.MissionID]
+		mission.Targets = append(mission.Targets, t)
 	}
 
 	return missions, nil
 }
 
 func (s *MissionStorage) Update(ctx context.Context, mission *models.Mission) error {
-	query := `UPDATE missions SET complete = :complete WHERE id = :id`
+	query := `UPDATE missions SET priority = :priority, due_at = :due_at, overdue_at = :overdue_at, budget = :budget.amount, budget_currency = :budget.currency WHERE id = :id`
 	_, err := s.db.NamedExecContext(ctx, query, mission)
 	if err != nil {
 		return err
@@ -104,38 +211,473 @@ func (s *MissionStorage) Update(ctx context.Context, mission *models.Mission) er
 	return nil
 }
 
-func (s *MissionStorage) Delete(ctx context.Context, id uuid.UUID) error {
+func (s *MissionStorage) Delete(ctx context.Context, id uuid.UUID) (blobKeys []string, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	blobKeys, err = deleteOwned(ctx, tx, models.OwnerMission, id)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `DELETE FROM missions WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, id)
+	_, err = tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return blobKeys, nil
+}
+
+func (s *MissionStorage) Overdue(ctx context.Context, now time.Time) ([]*models.Mission, error) {
+	query := `SELECT ` + missionColumns + ` FROM missions m
+		WHERE m.due_at < ?
+		  AND m.overdue_at IS NULL
+		  AND m.status NOT IN (?, ?, ?)
+		  AND EXISTS (SELECT 1 FROM targets t WHERE t.mission_id = m.id AND t.complete = false)`
+	missions := []*models.Mission{}
+	err := s.db.SelectContext(ctx, &missions, query, now, models.MissionCompleted, models.MissionAborted, models.MissionFailed)
+	if err != nil {
+		return nil, err
+	}
+	return missions, nil
+}
+
+func (s *MissionStorage) MarkOverdue(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	query := `UPDATE missions SET overdue_at = ? WHERE id = ? AND overdue_at IS NULL`
+	res, err := s.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (s *MissionStorage) Unassigned(ctx context.Context, limit int) ([]*models.Mission, error) {
+	query := `SELECT ` + missionColumns + ` FROM missions m
+		WHERE m.cat_id IS NULL AND m.status = ?
+		ORDER BY ` + missionUrgency + `
+		LIMIT ?`
+	missions := []*models.Mission{}
+	err := s.db.SelectContext(ctx, &missions, query, models.MissionDraft, limit)
+	if err != nil {
+		return nil, err
+	}
+	return missions, nil
+}
+
+func (s *MissionStorage) ChangeCat(ctx context.Context, assignment *models.MissionAssignment, transition *models.MissionTransition) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	queryMission := `UPDATE missions SET cat_id = ? WHERE id = ? AND cat_id <=> ?`
+	res, err := tx.ExecContext(ctx, queryMission, assignment.ToCatId, assignment.MissionID, assignment.FromCatId)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if affected == 0 {
+		err = ErrMissionCatChanged
+		return err
+	}
+
+	queryAssignment := `INSERT INTO mission_assignments (id, mission_id, from_cat_id, to_cat_id, reason, created_at) VALUES (:id, :mission_id, :from_cat_id, :to_cat_id, :reason, :created_at)`
+	_, err = tx.NamedExecContext(ctx, queryAssignment, assignment)
+	if err != nil {
+		return err
+	}
+
+	if transition != nil {
+		err = insertTransition(ctx, tx, transition)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (s *MissionStorage) AssignCat(ctx context.Context, missionId, catId uuid.UUID) error {
-	query := `UPDATE missions SET cat_id = ? WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, catId, missionId)
+func (s *MissionStorage) Assignments(ctx context.Context, missionId uuid.UUID) ([]*models.MissionAssignment, error) {
+	query := `SELECT * FROM mission_assignments WHERE mission_id = ? ORDER BY created_at, id`
+	assignments := []*models.MissionAssignment{}
+	err := s.db.SelectContext(ctx, &assignments, query, missionId)
+	if err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+func (s *MissionStorage) AssignmentsByMissions(ctx context.Context, missionIds []uuid.UUID) ([]*models.MissionAssignment, error) {
+	query := `SELECT * FROM mission_assignments WHERE mission_id IN (?) ORDER BY mission_id, created_at, id`
+	return selectByMissions[*models.MissionAssignment](ctx, s.db, query, missionIds)
+}
+
+func (s *MissionStorage) AddTarget(ctx context.Context, missionId, targetId uuid.UUID) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	targetIds, err := lockMissionTargets(ctx, tx, missionId)
 	if err != nil {
 		return err
 	}
+	if len(targetIds) >= models.MaxMissionTargets {
+		err = ErrMissionTargetsFull
+		return err
+	}
+
+	query := `UPDATE targets SET mission_id = ?, position = ? WHERE id = ? AND mission_id IS NULL AND complete = false`
+	err = updateTargetMission(ctx, tx, query, missionId, len(targetIds), targetId)
+	return err
+}
+
+func (s *MissionStorage) DetachTarget(ctx context.Context, missionId, targetId uuid.UUID, requireDebrief bool) (completed bool, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	targetIds, err := lockMissionTargets(ctx, tx, missionId)
+	if err != nil {
+		return false, err
+	}
+	if !slices.Contains(targetIds, targetId) {
+		err = ErrTargetMissionChanged
+		return false, err
+	}
+	if len(targetIds) == 1 {
+		err = ErrMissionLastTarget
+		return false, err
+	}
+
+	query := `UPDATE targets SET mission_id = NULL, position = 0 WHERE id = ? AND mission_id = ? AND complete = false`
+	err = updateTargetMission(ctx, tx, query, targetId, missionId)
+	if err != nil {
+		return false, err
+	}
+
+	err = compactTargetPositions(ctx, tx, slices.DeleteFunc(targetIds, func(id uuid.UUID) bool { return id == targetId }))
+	if err != nil {
+		return false, err
+	}
+
+	completed, err = completeMissionIfDone(ctx, tx, missionId, requireDebrief)
+	return completed, err
+}
+
+func (s *MissionStorage) MoveTarget(ctx context.Context, fromMissionId, toMissionId, targetId uuid.UUID, requireDebrief bool) (completed bool, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	var fromIds, toIds []uuid.UUID
+	if fromMissionId.String() < toMissionId.String() {
+		fromIds, err = lockMissionTargets(ctx, tx, fromMissionId)
+		if err == nil {
+			toIds, err = lockMissionTargets(ctx, tx, toMissionId)
+		}
+	} else {
+		toIds, err = lockMissionTargets(ctx, tx, toMissionId)
+		if err == nil {
+			fromIds, err = lockMissionTargets(ctx, tx, fromMissionId)
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	if !slices.Contains(fromIds, targetId) {
+		err = ErrTargetMissionChanged
+		return false, err
+	}
+	if len(fromIds) == 1 {
+		err = ErrMissionLastTarget
+		return false, err
+	}
+	if len(toIds) >= models.MaxMissionTargets {
+		err = ErrMissionTargetsFull
+		return false, err
+	}
+
+	query := `UPDATE targets SET mission_id = ?, position = ? WHERE id = ? AND mission_id = ? AND complete = false`
+	err = updateTargetMission(ctx, tx, query, toMissionId, len(toIds), targetId, fromMissionId)
+	if err != nil {
+		return false, err
+	}
+
+	err = compactTargetPositions(ctx, tx, slices.DeleteFunc(fromIds, func(id uuid.UUID) bool { return id == targetId }))
+	if err != nil {
+		return false, err
+	}
+
+	completed, err = completeMissionIfDone(ctx, tx, fromMissionId, requireDebrief)
+	return completed, err
+}
+
+func lockMissionTargets(ctx context.Context, tx *sqlx.Tx, missionId uuid.UUID) ([]uuid.UUID, error) {
+	queryMission := `SELECT status FROM missions WHERE id = ? FOR UPDATE`
+	var status models.MissionStatus
+	err := tx.GetContext(ctx, &status, queryMission, missionId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMissionNotFound
+		}
+		return nil, err
+	}
+	if status.IsFinal() {
+		return nil, ErrMissionStatusChanged
+	}
+
+	queryTargets := `SELECT id FROM targets WHERE mission_id = ? ORDER BY position, id FOR UPDATE`
+	targetIds := []uuid.UUID{}
+	err = tx.SelectContext(ctx, &targetIds, queryTargets, missionId)
+	if err != nil {
+		return nil, err
+	}
+	return targetIds, nil
+}
+
+func updateTargetMission(ctx context.Context, tx *sqlx.Tx, query string, args ...any) error {
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTargetMissionChanged
+	}
+	return nil
+}
+
+func compactTargetPositions(ctx context.Context, tx *sqlx.Tx, targetIds []uuid.UUID) error {
+	query := `UPDATE targets SET position = ? WHERE id = ?`
+	for i, id := range targetIds {
+		_, err := tx.ExecContext(ctx, query, i, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MissionStorage) ReorderTargets(ctx context.Context, missionId uuid.UUID, targetIds []uuid.UUID) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `UPDATE targets SET position = ? WHERE id = ? AND mission_id = ?`
+	for i, id := range targetIds {
+		_, err = tx.ExecContext(ctx, query, i, id, missionId)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func (s *MissionStorage) AddTarget(ctx context.Context, missionId uuid.UUID, target *models.Target) error {
-	query := `UPDATE targets SET mission_id = ? WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, missionId, target.ID)
+func (s *MissionStorage) Transition(ctx context.Context, transition *models.MissionTransition) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = insertTransition(ctx, tx, transition)
+	return err
+}
+
+func insertTransition(ctx context.Context, tx *sqlx.Tx, transition *models.MissionTransition) error {
+	queryMission := `UPDATE missions SET status = ? WHERE id = ? AND status = ?`
+	res, err := tx.ExecContext(ctx, queryMission, transition.ToStatus, transition.MissionID, transition.FromStatus)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrMissionStatusChanged
+	}
+
+	queryTransition := `INSERT INTO mission_transitions (id, mission_id, from_status, to_status, reason, created_at) VALUES (:id, :mission_id, :from_status, :to_status, :reason, :created_at)`
+	_, err = tx.NamedExecContext(ctx, queryTransition, transition)
+	return err
+}
+
+func completeMissionIfDone(ctx context.Context, tx *sqlx.Tx, missionId uuid.UUID, requireDebrief bool) (bool, error) {
+	queryMission := `SELECT status FROM missions WHERE id = ? FOR UPDATE`
+	var status models.MissionStatus
+	err := tx.GetContext(ctx, &status, queryMission, missionId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return false, ErrMissionNotFound
+		}
+		return false, err
+	}
+	queryTargets := `SELECT complete FROM targets WHERE mission_id = ? FOR UPDATE`
+	var complete []bool
+	err = tx.SelectContext(ctx, &complete, queryTargets, missionId)
+	if err != nil {
+		return false, err
+	}
+	if !status.AutoCompletes(complete) {
+		return false, nil
+	}
+
+	if requireDebrief {
+		queryDebrief := `SELECT EXISTS(SELECT 1 FROM mission_debriefs WHERE mission_id = ?)`
+		var exists bool
+		err = tx.GetContext(ctx, &exists, queryDebrief, missionId)
+		if err != nil || !exists {
+			return false, err
+		}
+	}
+
+	err = insertTransition(ctx, tx, &models.MissionTransition{
+		ID:         uuid.New(),
+		MissionID:  missionId,
+		FromStatus: &status,
+		ToStatus:   models.MissionCompleted,
+		CreatedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *MissionStorage) Transitions(ctx context.Context, missionId uuid.UUID) ([]*models.MissionTransition, error) {
+	query := `SELECT * FROM mission_transitions WHERE mission_id = ? ORDER BY created_at, id`
+	transitions := []*models.MissionTransition{}
+	err := s.db.SelectContext(ctx, &transitions, query, missionId)
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
+
+func (s *MissionStorage) TransitionsByMissions(ctx context.Context, missionIds []uuid.UUID) ([]*models.MissionTransition, error) {
+	query := `SELECT * FROM mission_transitions WHERE mission_id IN (?) ORDER BY mission_id, created_at, id`
+	return selectByMissions[*models.MissionTransition](ctx, s.db, query, missionIds)
+}
+
+func (s *MissionStorage) AddExpense(ctx context.Context, expense *models.MissionExpense) error {
+	query := `INSERT INTO mission_expenses (id, mission_id, description, amount, currency, incurred_at, created_at) VALUES (:id, :mission_id, :description, :amount.amount, :amount.currency, :incurred_at, :created_at)`
+	_, err := s.db.NamedExecContext(ctx, query, expense)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *MissionStorage) MarkComplete(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE missions SET complete = true WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, id)
+func (s *MissionStorage) Expenses(ctx context.Context, missionId uuid.UUID) ([]*models.MissionExpense, error) {
+	query := `SELECT ` + expenseColumns + ` FROM mission_expenses WHERE mission_id = ? ORDER BY incurred_at, id`
+	expenses := []*models.MissionExpense{}
+	err := s.db.SelectContext(ctx, &expenses, query, missionId)
+	if err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (s *MissionStorage) ExpensesByMissions(ctx context.Context, missionIds []uuid.UUID) ([]*models.MissionExpense, error) {
+	query := `SELECT ` + expenseColumns + ` FROM mission_expenses WHERE mission_id IN (?) ORDER BY mission_id, incurred_at, id`
+	return selectByMissions[*models.MissionExpense](ctx, s.db, query, missionIds)
+}
+
+func (s *MissionStorage) DeleteExpense(ctx context.Context, missionId, expenseId uuid.UUID) error {
+	query := `DELETE FROM mission_expenses WHERE id = ? AND mission_id = ?`
+	res, err := s.db.ExecContext(ctx, query, expenseId, missionId)
 	if err != nil {
 		return err
 	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrExpenseNotFound
+	}
 	return nil
 }
+
+func selectByMissions[T any](ctx context.Context, db *sqlx.DB, query string, missionIds []uuid.UUID) ([]T, error) {
+	rows := []T{}
+	if len(missionIds) == 0 {
+		return rows, nil
+	}
+
+	query, args, err := sqlx.In(query, missionIds)
+	if err != nil {
+		return nil, err
+	}
+	err = db.SelectContext(ctx, &rows, db.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
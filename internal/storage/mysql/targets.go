@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	stderrors "errors"
+	"math"
+	"time"
 
 	"sca/internal/models"
 	"sca/pkg/errors"
@@ -12,7 +14,10 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
-var ErrTargetNotFound = errors.ErrNotFound{Msg: "Target not found"}
+var (
+	ErrTargetNotFound       = errors.ErrNotFound{Msg: "Target not found"}
+	ErrNoteRevisionNotFound = errors.ErrNotFound{Msg: "Note revision not found"}
+)
 
 type TargetStorage struct {
 	db *sqlx.DB
@@ -23,14 +28,32 @@ func NewTargetStorage(db *sqlx.DB) *TargetStorage {
 }
 
 func (s *TargetStorage) Create(ctx context.Context, target *models.Target) error {
-	query := `INSERT INTO targets (id, name, country, notes, complete) VALUES (:id, :name, :country, :notes, :complete)`
-	_, err := s.db.NamedExecContext(ctx, query, target)
+	tx, err := s.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = insertTarget(ctx, tx, target)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
+func (s *TargetStorage) CreateMany(ctx context.Context, targets []*models.Target, atomic bool) ([]error, error) {
+	return insertBulk(ctx, s.db, len(targets), atomic, func(tx *sqlx.Tx, i int) error {
+		return insertTarget(ctx, tx, targets[i])
+	})
+}
+
 func (s *TargetStorage) ById(ctx context.Context, id uuid.UUID) (*models.Target, error) {
 	query := `SELECT * FROM targets WHERE id = ?`
 	var target models.Target
@@ -41,6 +64,12 @@ func (s *TargetStorage) ById(ctx context.Context, id uuid.UUID) (*models.Target,
 		}
 		return nil, err
 	}
+
+	err = loadTargetSkills(ctx, s.db, []*models.Target{&target})
+	if err != nil {
+		return nil, err
+	}
+
 	return &target, nil
 }
 
@@ -51,32 +80,280 @@ func (s *TargetStorage) All(ctx context.Context) ([]*models.Target, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	err = loadTargetSkills(ctx, s.db, targets)
+	if err != nil {
+		return nil, err
+	}
 	return targets, nil
 }
 
-func (s *TargetStorage) Delete(ctx context.Context, id uuid.UUID) error {
+func (s *TargetStorage) SetRequiredSkills(ctx context.Context, targetId uuid.UUID, skills []string) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	query := `DELETE FROM target_skills WHERE target_id = ?`
+	_, err = tx.ExecContext(ctx, query, targetId)
+	if err != nil {
+		return err
+	}
+
+	err = insertTargetSkills(ctx, tx, targetId, skills)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *TargetStorage) Delete(ctx context.Context, id uuid.UUID) (blobKeys []string, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	blobKeys, err = deleteOwned(ctx, tx, models.OwnerTarget, id)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `DELETE FROM targets WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, id)
+	_, err = tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return blobKeys, nil
+}
+
+func (s *TargetStorage) MarkComplete(ctx context.Context, id uuid.UUID, requireDebrief bool) (completed bool, err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	queryTarget := `SELECT mission_id FROM targets WHERE id = ? FOR UPDATE`
+	var missionId *uuid.UUID
+	err = tx.GetContext(ctx, &missionId, queryTarget, id)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			err = ErrTargetNotFound
+		}
+		return false, err
+	}
+
+	queryComplete := `UPDATE targets SET complete = true WHERE id = ?`
+	_, err = tx.ExecContext(ctx, queryComplete, id)
+	if err != nil {
+		return false, err
+	}
+
+	if missionId == nil {
+		return false, nil
+	}
+	return completeMissionIfDone(ctx, tx, *missionId, requireDebrief)
+}
+
+func (s *TargetStorage) UpdateNotes(ctx context.Context, revision *models.NoteRevision) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	queryTarget := `SELECT id FROM targets WHERE id = ? FOR UPDATE`
+	var id uuid.UUID
+	err = tx.GetContext(ctx, &id, queryTarget, revision.TargetID)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			err = ErrTargetNotFound
+		}
+		return err
+	}
+
+	queryVersion := `SELECT COALESCE(MAX(version), 0) FROM target_note_revisions WHERE target_id = ?`
+	var version int
+	err = tx.GetContext(ctx, &version, queryVersion, revision.TargetID)
+	if err != nil {
+		return err
+	}
+	revision.Version = version + 1
+
+	err = insertNoteRevision(ctx, tx, revision)
 	if err != nil {
 		return err
 	}
+
+	queryNotes := `UPDATE targets SET notes = ? WHERE id = ?`
+	_, err = tx.ExecContext(ctx, queryNotes, revision.Notes, revision.TargetID)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (s *TargetStorage) MarkComplete(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE targets SET complete = true WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, id)
+func (s *TargetStorage) UpdateLocation(ctx context.Context, target *models.Target) error {
+	query := `UPDATE targets SET latitude = :latitude, longitude = :longitude, last_seen_at = :last_seen_at WHERE id = :id`
+	_, err := s.db.NamedExecContext(ctx, query, target)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *TargetStorage) Nearby(ctx context.Context, lat, lng, radiusKm float64) ([]*models.NearbyTarget, error) {
+	const kmPerDegree = 111.32
+
+	dLat := radiusKm / kmPerDegree
+	conditions := `latitude BETWEEN ? AND ?`
+	args := []any{lat, lng, lat, lat - dLat, lat + dLat}
+
+	if cosLat := math.Cos(lat * math.Pi / 180); cosLat > 0.01 {
+		dLng := radiusKm / (kmPerDegree * cosLat)
+		if lng-dLng >= -180 && lng+dLng <= 180 {
+			conditions += ` AND longitude BETWEEN ? AND ?`
+			args = append(args, lng-dLng, lng+dLng)
+		}
+	}
+	args = append(args, radiusKm)
+
+	query := `SELECT *, 6371 * ACOS(LEAST(1, GREATEST(-1,
+			COS(RADIANS(?)) * COS(RADIANS(latitude)) * COS(RADIANS(longitude) - RADIANS(?)) +
+			SIN(RADIANS(?)) * SIN(RADIANS(latitude))))) AS distance_km
+		FROM targets
+		WHERE latitude IS NOT NULL AND longitude IS NOT NULL AND ` + conditions + `
+		HAVING distance_km <= ?
+		ORDER BY distance_km`
+	targets := []*models.NearbyTarget{}
+	err := s.db.SelectContext(ctx, &targets, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+func (s *TargetStorage) NoteRevisions(ctx context.Context, targetId uuid.UUID) ([]*models.NoteRevision, error) {
+	query := `SELECT * FROM target_note_revisions WHERE target_id = ? ORDER BY version`
+	revisions := []*models.NoteRevision{}
+	err := s.db.SelectContext(ctx, &revisions, query, targetId)
+	if err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (s *TargetStorage) NoteRevision(ctx context.Context, targetId uuid.UUID, version int) (*models.NoteRevision, error) {
+	query := `SELECT * FROM target_note_revisions WHERE target_id = ? AND version = ?`
+	var revision models.NoteRevision
+	err := s.db.GetContext(ctx, &revision, query, targetId, version)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoteRevisionNotFound
+		}
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func insertNoteRevision(ctx context.Context, tx *sqlx.Tx, revision *models.NoteRevision) error {
+	query := `INSERT INTO target_note_revisions (id, target_id, version, notes, author, created_at) VALUES (:id, :target_id, :version, :notes, :author, :created_at)`
+	_, err := tx.NamedExecContext(ctx, query, revision)
+	return err
+}
+
+func insertTarget(ctx context.Context, tx *sqlx.Tx, target *models.Target) error {
+	query := `INSERT INTO targets (id, name, country, latitude, longitude, last_seen_at, notes, complete) VALUES (:id, :name, :country, :latitude, :longitude, :last_seen_at, :notes, :complete)`
+	_, err := tx.NamedExecContext(ctx, query, target)
 	if err != nil {
 		return err
 	}
+
+	err = insertNoteRevision(ctx, tx, &models.NoteRevision{
+		ID:        uuid.New(),
+		TargetID:  target.ID,
+		Version:   1,
+		Notes:     target.Notes,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = insertTargetSkills(ctx, tx, target.ID, target.RequiredSkills)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (s *TargetStorage) UpdateNotes(ctx context.Context, id uuid.UUID, notes string) error {
-	query := `UPDATE targets SET notes = ? WHERE id = ?`
-	_, err := s.db.ExecContext(ctx, query, notes, id)
+func insertTargetSkills(ctx context.Context, tx *sqlx.Tx, targetId uuid.UUID, skills []string) error {
+	query := `INSERT INTO target_skills (target_id, skill) VALUES (?, ?)`
+	for _, skill := range skills {
+		if _, err := tx.ExecContext(ctx, query, targetId, skill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func loadTargetSkills(ctx context.Context, db *sqlx.DB, targets []*models.Target) error {
+	if len(targets) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(targets))
+	byId := make(map[uuid.UUID]*models.Target, len(targets))
+	for i, t := range targets {
+		ids[i] = t.ID
+		byId[t.ID] = t
+		t.RequiredSkills = []string{}
+	}
+
+	query, args, err := sqlx.In(`SELECT target_id, skill FROM target_skills WHERE target_id IN (?) ORDER BY skill`, ids)
+	if err != nil {
+		return err
+	}
+
+	var rows []struct {
+		TargetID uuid.UUID `db:"target_id"`
+		Skill    string    `db:"skill"`
+	}
+	err = db.SelectContext(ctx, &rows, db.Rebind(query), args...)
 	if err != nil {
 		return err
 	}
+	for _, r := range rows {
+		byId[r.TargetID].RequiredSkills = append(byId[r.TargetID].RequiredSkills, r.Skill)
+	}
 	return nil
 }
@@ -0,0 +1,127 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+
+	"sca/internal/models"
+	"sca/pkg/database/mysql"
+	"sca/pkg/errors"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	ErrTemplateAlreadyExists = errors.ErrConflict{Msg: "Mission template is already exists"}
+	ErrTemplateNotFound      = errors.ErrNotFound{Msg: "Mission template not found"}
+)
+
+const templateColumns = "id, name, priority, budget AS `budget.amount`, budget_currency AS `budget.currency`, created_at"
+
+type TemplateStorage struct {
+	db *sqlx.DB
+}
+
+func NewTemplateStorage(db *sqlx.DB) *TemplateStorage {
+	return &TemplateStorage{db: db}
+}
+
+func (s *TemplateStorage) Create(ctx context.Context, template *models.MissionTemplate) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	queryTemplate := `INSERT INTO mission_templates (id, name, priority, budget, budget_currency, created_at) VALUES (:id, :name, :priority, :budget.amount, :budget.currency, :created_at)`
+	_, err = tx.NamedExecContext(ctx, queryTemplate, template)
+	if err != nil {
+		if mysql.IsDuplicate(err) {
+			err = ErrTemplateAlreadyExists
+		}
+		return err
+	}
+
+	queryTarget := `INSERT INTO mission_template_targets (id, template_id, position, name, country, notes, required_skills) VALUES (:id, :template_id, :position, :name, :country, :notes, :required_skills)`
+	for _, t := range template.Targets {
+		_, err = tx.NamedExecContext(ctx, queryTarget, t)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *TemplateStorage) ById(ctx context.Context, id uuid.UUID) (*models.MissionTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM mission_templates WHERE id = ?`
+	var template models.MissionTemplate
+	err := s.db.GetContext(ctx, &template, query, id)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	targets, err := s.targets(ctx, template.ID)
+	if err != nil {
+		return nil, err
+	}
+	template.Targets = targets
+
+	return &template, nil
+}
+
+func (s *TemplateStorage) All(ctx context.Context) ([]*models.MissionTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM mission_templates ORDER BY name`
+	templates := []*models.MissionTemplate{}
+	err := s.db.SelectContext(ctx, &templates, query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates {
+		targets, err := s.targets(ctx, template.ID)
+		if err != nil {
+			return nil, err
+		}
+		template.Targets = targets
+	}
+
+	return templates, nil
+}
+
+func (s *TemplateStorage) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM mission_templates WHERE id = ?`
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+func (s *TemplateStorage) targets(ctx context.Context, templateId uuid.UUID) ([]*models.TemplateTarget, error) {
+	query := `SELECT * FROM mission_template_targets WHERE template_id = ? ORDER BY position`
+	targets := []*models.TemplateTarget{}
+	err := s.db.SelectContext(ctx, &targets, query, templateId)
+	if err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
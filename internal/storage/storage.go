@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"sca/internal/models"
 	"sca/internal/storage/mysql"
@@ -12,42 +13,138 @@ import (
 
 type CatStorage interface {
 	Create(ctx context.Context, cat *models.Cat) error
+	CreateMany(ctx context.Context, cats []*models.Cat, atomic bool) ([]error, error)
 	ById(ctx context.Context, id uuid.UUID) (*models.Cat, error)
-	All(ctx context.Context) ([]*models.Cat, error)
+	All(ctx context.Context, filter models.CatFilter) ([]*models.Cat, error)
 	Update(ctx context.Context, cat *models.Cat) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Available(ctx context.Context, from, to time.Time) ([]*models.Cat, error)
+	AddLeave(ctx context.Context, leave *models.CatLeave) error
+	Leaves(ctx context.Context, catId uuid.UUID) ([]*models.CatLeave, error)
+	DeleteLeave(ctx context.Context, catId, leaveId uuid.UUID) error
+	UpdateSalary(ctx context.Context, change *models.SalaryChange) error
+	SalaryChanges(ctx context.Context, catId uuid.UUID) ([]*models.SalaryChange, error)
+	SalaryChangesUntil(ctx context.Context, until time.Time) ([]*models.SalaryChange, error)
+	SalaryChangesByCats(ctx context.Context, catIds []uuid.UUID) ([]*models.SalaryChange, error)
+	Workloads(ctx context.Context) ([]*models.CatWorkload, error)
+	SetSkills(ctx context.Context, catId uuid.UUID, skills []string) error
+	CountryRecords(ctx context.Context, countries []string) ([]*models.CatCountryRecord, error)
+	Stats(ctx context.Context, catId uuid.UUID) (*models.CatStats, error)
+	Leaderboard(ctx context.Context, filter models.CatLeaderboardFilter) ([]*models.CatStats, error)
 }
 
 type MissionStorage interface {
 	Create(ctx context.Context, mission *models.Mission, targets []*models.Target) error
 	ById(ctx context.Context, id uuid.UUID) (*models.Mission, error)
-	All(ctx context.Context) ([]*models.Mission, error)
+	All(ctx context.Context, filter models.MissionFilter) ([]*models.Mission, error)
 	Update(ctx context.Context, mission *models.Mission) error
-	Delete(ctx context.Context, id uuid.UUID) error
-	AssignCat(ctx context.Context, missionId, catId uuid.UUID) error
-	AddTarget(ctx context.Context, missionId uuid.UUID, target *models.Target) error
-	MarkComplete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) ([]string, error)
+	ChangeCat(ctx context.Context, assignment *models.MissionAssignment, transition *models.MissionTransition) error
+	Assignments(ctx context.Context, missionId uuid.UUID) ([]*models.MissionAssignment, error)
+	AssignmentsByMissions(ctx context.Context, missionIds []uuid.UUID) ([]*models.MissionAssignment, error)
+	AddTarget(ctx context.Context, missionId, targetId uuid.UUID) error
+	DetachTarget(ctx context.Context, missionId, targetId uuid.UUID, requireDebrief bool) (bool, error)
+	MoveTarget(ctx context.Context, fromMissionId, toMissionId, targetId uuid.UUID, requireDebrief bool) (bool, error)
+	ReorderTargets(ctx context.Context, missionId uuid.UUID, targetIds []uuid.UUID) error
+	Transition(ctx context.Context, transition *models.MissionTransition) error
+	Transitions(ctx context.Context, missionId uuid.UUID) ([]*models.MissionTransition, error)
+	TransitionsByMissions(ctx context.Context, missionIds []uuid.UUID) ([]*models.MissionTransition, error)
+	Overdue(ctx context.Context, now time.Time) ([]*models.Mission, error)
+	MarkOverdue(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	Unassigned(ctx context.Context, limit int) ([]*models.Mission, error)
+	AddExpense(ctx context.Context, expense *models.MissionExpense) error
+	Expenses(ctx context.Context, missionId uuid.UUID) ([]*models.MissionExpense, error)
+	ExpensesByMissions(ctx context.Context, missionIds []uuid.UUID) ([]*models.MissionExpense, error)
+	DeleteExpense(ctx context.Context, missionId, expenseId uuid.UUID) error
 }
 
 type TargetStorage interface {
 	Create(ctx context.Context, target *models.Target) error
+	CreateMany(ctx context.Context, targets []*models.Target, atomic bool) ([]error, error)
 	ById(ctx context.Context, id uuid.UUID) (*models.Target, error)
 	All(ctx context.Context) ([]*models.Target, error)
+	Delete(ctx context.Context, id uuid.UUID) ([]string, error)
+	MarkComplete(ctx context.Context, id uuid.UUID, requireDebrief bool) (bool, error)
+	UpdateNotes(ctx context.Context, revision *models.NoteRevision) error
+	UpdateLocation(ctx context.Context, target *models.Target) error
+	SetRequiredSkills(ctx context.Context, targetId uuid.UUID, skills []string) error
+	Nearby(ctx context.Context, lat, lng, radiusKm float64) ([]*models.NearbyTarget, error)
+	NoteRevisions(ctx context.Context, targetId uuid.UUID) ([]*models.NoteRevision, error)
+	NoteRevision(ctx context.Context, targetId uuid.UUID, version int) (*models.NoteRevision, error)
+}
+
+type TemplateStorage interface {
+	Create(ctx context.Context, template *models.MissionTemplate) error
+	ById(ctx context.Context, id uuid.UUID) (*models.MissionTemplate, error)
+	All(ctx context.Context) ([]*models.MissionTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type AttachmentStorage interface {
+	Create(ctx context.Context, attachment *models.Attachment) error
+	ById(ctx context.Context, id uuid.UUID) (*models.Attachment, error)
+	ByOwner(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type CommentStorage interface {
+	Create(ctx context.Context, comment *models.Comment) error
+	ById(ctx context.Context, id uuid.UUID) (*models.Comment, error)
+	ByOwner(ctx context.Context, ownerType models.OwnerType, ownerId uuid.UUID) ([]*models.Comment, error)
+	Update(ctx context.Context, comment *models.Comment) error
+	CountReplies(ctx context.Context, id uuid.UUID) (int, error)
 	Delete(ctx context.Context, id uuid.UUID) error
-	MarkComplete(ctx context.Context, id uuid.UUID) error
-	UpdateNotes(ctx context.Context, id uuid.UUID, notes string) error
+}
+
+type DebriefStorage interface {
+	Save(ctx context.Context, debrief *models.MissionDebrief, completeMission bool) (bool, error)
+	ByMission(ctx context.Context, missionId uuid.UUID) (*models.MissionDebrief, error)
+	Exists(ctx context.Context, missionId uuid.UUID) (bool, error)
+}
+
+type DashboardStorage interface {
+	CatAvailability(ctx context.Context, now time.Time) (*models.CatAvailability, error)
+	MissionCounts(ctx context.Context) ([]*models.MissionStatusCount, error)
+	OpenTargets(ctx context.Context) ([]*models.CountryCount, error)
+	Unassigned(ctx context.Context, now time.Time) (*models.UnassignedMissions, error)
+	CompletionTrends(ctx context.Context, since time.Time) ([]*models.CompletionTrend, error)
+}
+
+type BackupStorage interface {
+	SchemaVersion(ctx context.Context) (uint, bool, error)
+	Counts(ctx context.Context, tables []string) (map[string]int, error)
+	Dump(ctx context.Context, tables []string, visit func(table string, row map[string]any) error) error
+	Load(ctx context.Context, load func(insert func(table string, row map[string]any) error) error) error
+}
+
+type LockStorage interface {
+	TryLock(ctx context.Context, name string) (func(), bool, error)
 }
 
 type Storage struct {
-	CatStorage     CatStorage
-	TargetStorage  TargetStorage
-	MissionStorage MissionStorage
+	CatStorage        CatStorage
+	TargetStorage     TargetStorage
+	MissionStorage    MissionStorage
+	TemplateStorage   TemplateStorage
+	AttachmentStorage AttachmentStorage
+	CommentStorage    CommentStorage
+	DebriefStorage    DebriefStorage
+	DashboardStorage  DashboardStorage
+	BackupStorage     BackupStorage
+	LockStorage       LockStorage
 }
 
 func NewStorage(db *sqlx.DB) *Storage {
 	return &Storage{
-		CatStorage:     mysql.NewCatStorage(db),
-		TargetStorage:  mysql.NewTargetStorage(db),
-		MissionStorage: mysql.NewMissionStorage(db),
+		CatStorage:        mysql.NewCatStorage(db),
+		TargetStorage:     mysql.NewTargetStorage(db),
+		MissionStorage:    mysql.NewMissionStorage(db),
+		TemplateStorage:   mysql.NewTemplateStorage(db),
+		AttachmentStorage: mysql.NewAttachmentStorage(db),
+		CommentStorage:    mysql.NewCommentStorage(db),
+		DebriefStorage:    mysql.NewDebriefStorage(db),
+		DashboardStorage:  mysql.NewDashboardStorage(db),
+		BackupStorage:     mysql.NewBackupStorage(db),
+		LockStorage:       mysql.NewLockStorage(db),
 	}
 }
@@ -0,0 +1 @@
+DO 0;
\ No newline at end of file
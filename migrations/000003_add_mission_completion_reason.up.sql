@@ -0,0 +1,3 @@
+-- Superseded: mission completion reasons are recorded in mission_transitions (000004).
+-- Kept as a no-op so databases that already applied version 3 can keep migrating.
+DO 0;
\ No newline at end of file
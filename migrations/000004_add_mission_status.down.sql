@@ -0,0 +1,10 @@
+ALTER TABLE missions
+    ADD COLUMN complete BOOLEAN NOT NULL DEFAULT false AFTER id;
+
+UPDATE missions
+SET complete = (status = 'completed');
+
+DROP TABLE IF EXISTS mission_transitions;
+
+ALTER TABLE missions
+    DROP COLUMN status;
\ No newline at end of file
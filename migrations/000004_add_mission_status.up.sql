@@ -0,0 +1,37 @@
+ALTER TABLE missions
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'draft' AFTER id;
+
+UPDATE missions
+SET status = CASE
+                 WHEN complete THEN 'completed'
+                 WHEN cat_id IS NOT NULL THEN 'assigned'
+                 ELSE 'draft'
+    END;
+
+CREATE TABLE IF NOT EXISTS mission_transitions
+(
+    id          CHAR(36)     NOT NULL,
+    mission_id  CHAR(36)     NOT NULL,
+    from_status VARCHAR(16)  NULL,
+    to_status   VARCHAR(16)  NOT NULL,
+    reason      VARCHAR(255) NULL,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE CASCADE
+);
+
+INSERT INTO mission_transitions (id, mission_id, from_status, to_status)
+SELECT UUID(), id, NULL, status
+FROM missions;
+
+ALTER TABLE missions
+    DROP COLUMN complete;
+
+SET @drop_completion_reason = (SELECT IF(COUNT(*) > 0, 'ALTER TABLE missions DROP COLUMN completion_reason', 'DO 0')
+                               FROM information_schema.columns
+                               WHERE table_schema = DATABASE()
+                                 AND table_name = 'missions'
+                                 AND column_name = 'completion_reason');
+PREPARE drop_completion_reason FROM @drop_completion_reason;
+EXECUTE drop_completion_reason;
+DEALLOCATE PREPARE drop_completion_reason;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mission_assignments;
\ No newline at end of file
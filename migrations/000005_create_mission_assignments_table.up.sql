@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS mission_assignments
+(
+    id          CHAR(36)     NOT NULL,
+    mission_id  CHAR(36)     NOT NULL,
+    from_cat_id CHAR(36)     NULL,
+    to_cat_id   CHAR(36)     NULL,
+    reason      VARCHAR(255) NULL,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE CASCADE
+);
+
+INSERT INTO mission_assignments (id, mission_id, from_cat_id, to_cat_id, reason, created_at)
+SELECT UUID(), m.id, NULL, m.cat_id, NULL, (SELECT MIN(t.created_at) FROM mission_transitions t WHERE t.mission_id = m.id)
+FROM missions m
+WHERE m.cat_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM mission_assignments a WHERE a.mission_id = m.id);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE targets
+    DROP COLUMN position;
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE targets
+    ADD COLUMN position INT NOT NULL DEFAULT 0 AFTER mission_id;
+
+UPDATE targets t
+    JOIN (SELECT id, ROW_NUMBER() OVER (PARTITION BY mission_id ORDER BY id) - 1 AS pos
+          FROM targets
+          WHERE mission_id IS NOT NULL) p ON p.id = t.id
+SET t.position = p.pos;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS target_note_revisions
+(
+    id         CHAR(36)    NOT NULL,
+    target_id  CHAR(36)    NOT NULL,
+    version    INT         NOT NULL,
+    notes      TEXT        NOT NULL,
+    author     VARCHAR(64) NULL,
+    created_at DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE (target_id, version),
+    FOREIGN KEY (target_id) REFERENCES targets (id) ON DELETE CASCADE
+);
+
+INSERT INTO target_note_revisions (id, target_id, version, notes, author)
+SELECT UUID(), id, 1, notes, NULL
+FROM targets;
\ No newline at end of file
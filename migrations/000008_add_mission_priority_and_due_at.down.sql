@@ -0,0 +1,6 @@
+ALTER TABLE missions
+    DROP INDEX idx_missions_priority,
+    DROP INDEX idx_missions_due_at,
+    DROP COLUMN priority,
+    DROP COLUMN due_at,
+    DROP COLUMN overdue_at;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE missions
+    ADD COLUMN priority   TINYINT  NOT NULL DEFAULT 3 AFTER status,
+    ADD COLUMN due_at     DATETIME NULL AFTER priority,
+    ADD COLUMN overdue_at DATETIME NULL AFTER due_at,
+    ADD INDEX idx_missions_priority (priority),
+    ADD INDEX idx_missions_due_at (due_at);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS cat_leaves;
+
+ALTER TABLE cats
+    DROP COLUMN status;
\ No newline at end of file
@@ -0,0 +1,14 @@
+ALTER TABLE cats
+    ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'active' AFTER breed;
+
+CREATE TABLE IF NOT EXISTS cat_leaves
+(
+    id        CHAR(36)     NOT NULL,
+    cat_id    CHAR(36)     NOT NULL,
+    starts_at DATETIME     NOT NULL,
+    ends_at   DATETIME     NOT NULL,
+    reason    VARCHAR(255) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_cat_leaves_period (cat_id, starts_at, ends_at),
+    FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS cat_salary_changes;
\ No newline at end of file
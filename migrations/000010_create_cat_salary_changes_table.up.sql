@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS cat_salary_changes
+(
+    id             CHAR(36)      NOT NULL,
+    cat_id         CHAR(36)      NOT NULL,
+    salary         DECIMAL(8, 2) NOT NULL,
+    effective_from DATE          NOT NULL,
+    approved_by    VARCHAR(64)   NULL,
+    created_at     DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_cat_salary_changes_effective (cat_id, effective_from),
+    FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+);
+
+-- cats has no creation timestamp, so the real start date of the existing salaries is unknown.
+-- They are backfilled as effective from the day this migration runs, which means payroll for
+-- any period before that date reports no salary for cats that existed before this migration.
+INSERT INTO cat_salary_changes (id, cat_id, salary, effective_from, approved_by)
+SELECT UUID(), id, salary, CURRENT_DATE, NULL
+FROM cats;
\ No newline at end of file
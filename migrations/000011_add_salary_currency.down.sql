@@ -0,0 +1,5 @@
+ALTER TABLE cat_salary_changes
+    DROP COLUMN currency;
+
+ALTER TABLE cats
+    DROP COLUMN salary_currency;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE cats
+    ADD COLUMN salary_currency CHAR(3) NOT NULL DEFAULT 'USD' AFTER salary;
+
+ALTER TABLE cat_salary_changes
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD' AFTER salary;
\ No newline at end of file
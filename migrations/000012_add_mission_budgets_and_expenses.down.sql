@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS mission_expenses;
+
+ALTER TABLE missions
+    DROP COLUMN budget,
+    DROP COLUMN budget_currency;
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE missions
+    ADD COLUMN budget          DECIMAL(10, 2) NOT NULL DEFAULT 0 AFTER overdue_at,
+    ADD COLUMN budget_currency CHAR(3)        NOT NULL DEFAULT 'USD' AFTER budget;
+
+CREATE TABLE IF NOT EXISTS mission_expenses
+(
+    id          CHAR(36)       NOT NULL,
+    mission_id  CHAR(36)       NOT NULL,
+    description VARCHAR(255)   NOT NULL,
+    amount      DECIMAL(10, 2) NOT NULL,
+    currency    CHAR(3)        NOT NULL DEFAULT 'USD',
+    incurred_at DATETIME       NOT NULL,
+    created_at  DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE CASCADE
+);
\ No newline at end of file
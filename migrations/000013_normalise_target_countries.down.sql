@@ -0,0 +1,2 @@
+-- Country normalisation cannot be undone: the original free-text values are not kept.
+DO 0;
\ No newline at end of file
@@ -0,0 +1,999 @@
+DROP TABLE IF EXISTS country_aliases;
+DROP PROCEDURE IF EXISTS check_target_countries;
+
+CREATE TABLE country_aliases
+(
+    alias VARCHAR(100) COLLATE utf8mb4_bin NOT NULL,
+    code  CHAR(2)                          NOT NULL,
+    PRIMARY KEY (alias)
+);
+
+INSERT INTO country_aliases (alias, code)
+VALUES ('AD', 'AD'),
+       ('AND', 'AD'),
+       ('ANDORRA', 'AD'),
+       ('PRINCIPALITY OF ANDORRA', 'AD'),
+       ('AE', 'AE'),
+       ('ARE', 'AE'),
+       ('UAE', 'AE'),
+       ('UNITED ARAB EMIRATES', 'AE'),
+       ('AF', 'AF'),
+       ('AFG', 'AF'),
+       ('AFGHANISTAN', 'AF'),
+       ('ISLAMIC REPUBLIC OF AFGHANISTAN', 'AF'),
+       ('AG', 'AG'),
+       ('ANTIGUA AND BARBUDA', 'AG'),
+       ('ATG', 'AG'),
+       ('AI', 'AI'),
+       ('AIA', 'AI'),
+       ('ANGUILLA', 'AI'),
+       ('AL', 'AL'),
+       ('ALB', 'AL'),
+       ('ALBANIA', 'AL'),
+       ('REPUBLIC OF ALBANIA', 'AL'),
+       ('AM', 'AM'),
+       ('ARM', 'AM'),
+       ('ARMENIA', 'AM'),
+       ('REPUBLIC OF ARMENIA', 'AM'),
+       ('AGO', 'AO'),
+       ('ANGOLA', 'AO'),
+       ('AO', 'AO'),
+       ('REPUBLIC OF ANGOLA', 'AO'),
+       ('ANTARCTICA', 'AQ'),
+       ('AQ', 'AQ'),
+       ('ATA', 'AQ'),
+       ('AR', 'AR'),
+       ('ARG', 'AR'),
+       ('ARGENTINA', 'AR'),
+       ('ARGENTINE REPUBLIC', 'AR'),
+       ('AMERICAN SAMOA', 'AS'),
+       ('AS', 'AS'),
+       ('ASM', 'AS'),
+       ('AT', 'AT'),
+       ('AUSTRIA', 'AT'),
+       ('AUT', 'AT'),
+       ('REPUBLIC OF AUSTRIA', 'AT'),
+       ('AU', 'AU'),
+       ('AUS', 'AU'),
+       ('AUSTRALIA', 'AU'),
+       ('ABW', 'AW'),
+       ('ARUBA', 'AW'),
+       ('AW', 'AW'),
+       ('ALA', 'AX'),
+       ('ALAND ISLANDS', 'AX'),
+       ('AX', 'AX'),
+       ('ÅLAND ISLANDS', 'AX'),
+       ('AZ', 'AZ'),
+       ('AZE', 'AZ'),
+       ('AZERBAIJAN', 'AZ'),
+       ('REPUBLIC OF AZERBAIJAN', 'AZ'),
+       ('BA', 'BA'),
+       ('BIH', 'BA'),
+       ('BOSNIA AND HERZEGOVINA', 'BA'),
+       ('REPUBLIC OF BOSNIA AND HERZEGOVINA', 'BA'),
+       ('BARBADOS', 'BB'),
+       ('BB', 'BB'),
+       ('BRB', 'BB'),
+       ('BANGLADESH', 'BD'),
+       ('BD', 'BD'),
+       ('BGD', 'BD'),
+       ('PEOPLE''S REPUBLIC OF BANGLADESH', 'BD'),
+       ('BE', 'BE'),
+       ('BEL', 'BE'),
+       ('BELGIUM', 'BE'),
+       ('KINGDOM OF BELGIUM', 'BE'),
+       ('BF', 'BF'),
+       ('BFA', 'BF'),
+       ('BURKINA FASO', 'BF'),
+       ('BG', 'BG'),
+       ('BGR', 'BG'),
+       ('BULGARIA', 'BG'),
+       ('REPUBLIC OF BULGARIA', 'BG'),
+       ('BAHRAIN', 'BH'),
+       ('BH', 'BH'),
+       ('BHR', 'BH'),
+       ('KINGDOM OF BAHRAIN', 'BH'),
+       ('BDI', 'BI'),
+       ('BI', 'BI'),
+       ('BURUNDI', 'BI'),
+       ('REPUBLIC OF BURUNDI', 'BI'),
+       ('BEN', 'BJ'),
+       ('BENIN', 'BJ'),
+       ('BJ', 'BJ'),
+       ('REPUBLIC OF BENIN', 'BJ'),
+       ('BL', 'BL'),
+       ('BLM', 'BL'),
+       ('SAINT BARTHELEMY', 'BL'),
+       ('SAINT BARTHÉLEMY', 'BL'),
+       ('BERMUDA', 'BM'),
+       ('BM', 'BM'),
+       ('BMU', 'BM'),
+       ('BN', 'BN'),
+       ('BRN', 'BN'),
+       ('BRUNEI', 'BN'),
+       ('BRUNEI DARUSSALAM', 'BN'),
+       ('BO', 'BO'),
+       ('BOL', 'BO'),
+       ('BOLIVIA', 'BO'),
+       ('BOLIVIA, PLURINATIONAL STATE OF', 'BO'),
+       ('PLURINATIONAL STATE OF BOLIVIA', 'BO'),
+       ('BES', 'BQ'),
+       ('BONAIRE, SINT EUSTATIUS AND SABA', 'BQ'),
+       ('BQ', 'BQ'),
+       ('BR', 'BR'),
+       ('BRA', 'BR'),
+       ('BRAZIL', 'BR'),
+       ('FEDERATIVE REPUBLIC OF BRAZIL', 'BR'),
+       ('BAHAMAS', 'BS'),
+       ('BHS', 'BS'),
+       ('BS', 'BS'),
+       ('COMMONWEALTH OF THE BAHAMAS', 'BS'),
+       ('THE BAHAMAS', 'BS'),
+       ('BHUTAN', 'BT'),
+       ('BT', 'BT'),
+       ('BTN', 'BT'),
+       ('KINGDOM OF BHUTAN', 'BT'),
+       ('BOUVET ISLAND', 'BV'),
+       ('BV', 'BV'),
+       ('BVT', 'BV'),
+       ('BOTSWANA', 'BW'),
+       ('BW', 'BW'),
+       ('BWA', 'BW'),
+       ('REPUBLIC OF BOTSWANA', 'BW'),
+       ('BELARUS', 'BY'),
+       ('BLR', 'BY'),
+       ('BY', 'BY'),
+       ('REPUBLIC OF BELARUS', 'BY'),
+       ('BELIZE', 'BZ'),
+       ('BLZ', 'BZ'),
+       ('BZ', 'BZ'),
+       ('CA', 'CA'),
+       ('CAN', 'CA'),
+       ('CANADA', 'CA'),
+       ('CC', 'CC'),
+       ('CCK', 'CC'),
+       ('COCOS (KEELING) ISLANDS', 'CC'),
+       ('CD', 'CD'),
+       ('COD', 'CD'),
+       ('CONGO, THE DEMOCRATIC REPUBLIC OF THE', 'CD'),
+       ('CONGO-KINSHASA', 'CD'),
+       ('DEMOCRATIC REPUBLIC OF THE CONGO', 'CD'),
+       ('DR CONGO', 'CD'),
+       ('CAF', 'CF'),
+       ('CENTRAL AFRICAN REPUBLIC', 'CF'),
+       ('CF', 'CF'),
+       ('CG', 'CG'),
+       ('COG', 'CG'),
+       ('CONGO', 'CG'),
+       ('CONGO-BRAZZAVILLE', 'CG'),
+       ('REPUBLIC OF THE CONGO', 'CG'),
+       ('CH', 'CH'),
+       ('CHE', 'CH'),
+       ('SWISS CONFEDERATION', 'CH'),
+       ('SWITZERLAND', 'CH'),
+       ('CI', 'CI'),
+       ('CIV', 'CI'),
+       ('COTE D''IVOIRE', 'CI'),
+       ('CÔTE D''IVOIRE', 'CI'),
+       ('IVORY COAST', 'CI'),
+       ('REPUBLIC OF COTE D''IVOIRE', 'CI'),
+       ('REPUBLIC OF CÔTE D''IVOIRE', 'CI'),
+       ('CK', 'CK'),
+       ('COK', 'CK'),
+       ('COOK ISLANDS', 'CK'),
+       ('CHILE', 'CL'),
+       ('CHL', 'CL'),
+       ('CL', 'CL'),
+       ('REPUBLIC OF CHILE', 'CL'),
+       ('CAMEROON', 'CM'),
+       ('CM', 'CM'),
+       ('CMR', 'CM'),
+       ('REPUBLIC OF CAMEROON', 'CM'),
+       ('CHINA', 'CN'),
+       ('CHN', 'CN'),
+       ('CN', 'CN'),
+       ('PEOPLE''S REPUBLIC OF CHINA', 'CN'),
+       ('CO', 'CO'),
+       ('COL', 'CO'),
+       ('COLOMBIA', 'CO'),
+       ('REPUBLIC OF COLOMBIA', 'CO'),
+       ('COSTA RICA', 'CR'),
+       ('CR', 'CR'),
+       ('CRI', 'CR'),
+       ('REPUBLIC OF COSTA RICA', 'CR'),
+       ('CU', 'CU'),
+       ('CUB', 'CU'),
+       ('CUBA', 'CU'),
+       ('REPUBLIC OF CUBA', 'CU'),
+       ('CABO VERDE', 'CV'),
+       ('CAPE VERDE', 'CV'),
+       ('CPV', 'CV'),
+       ('CV', 'CV'),
+       ('REPUBLIC OF CABO VERDE', 'CV'),
+       ('CURACAO', 'CW'),
+       ('CURAÇAO', 'CW'),
+       ('CUW', 'CW'),
+       ('CW', 'CW'),
+       ('CHRISTMAS ISLAND', 'CX'),
+       ('CX', 'CX'),
+       ('CXR', 'CX'),
+       ('CY', 'CY'),
+       ('CYP', 'CY'),
+       ('CYPRUS', 'CY'),
+       ('REPUBLIC OF CYPRUS', 'CY'),
+       ('CZ', 'CZ'),
+       ('CZE', 'CZ'),
+       ('CZECH REPUBLIC', 'CZ'),
+       ('CZECHIA', 'CZ'),
+       ('DE', 'DE'),
+       ('DEU', 'DE'),
+       ('FEDERAL REPUBLIC OF GERMANY', 'DE'),
+       ('GERMANY', 'DE'),
+       ('DJ', 'DJ'),
+       ('DJI', 'DJ'),
+       ('DJIBOUTI', 'DJ'),
+       ('REPUBLIC OF DJIBOUTI', 'DJ'),
+       ('DENMARK', 'DK'),
+       ('DK', 'DK'),
+       ('DNK', 'DK'),
+       ('KINGDOM OF DENMARK', 'DK'),
+       ('COMMONWEALTH OF DOMINICA', 'DM'),
+       ('DM', 'DM'),
+       ('DMA', 'DM'),
+       ('DOMINICA', 'DM'),
+       ('DO', 'DO'),
+       ('DOM', 'DO'),
+       ('DOMINICAN REPUBLIC', 'DO'),
+       ('ALGERIA', 'DZ'),
+       ('DZ', 'DZ'),
+       ('DZA', 'DZ'),
+       ('PEOPLE''S DEMOCRATIC REPUBLIC OF ALGERIA', 'DZ'),
+       ('EC', 'EC'),
+       ('ECU', 'EC'),
+       ('ECUADOR', 'EC'),
+       ('REPUBLIC OF ECUADOR', 'EC'),
+       ('EE', 'EE'),
+       ('EST', 'EE'),
+       ('ESTONIA', 'EE'),
+       ('REPUBLIC OF ESTONIA', 'EE'),
+       ('ARAB REPUBLIC OF EGYPT', 'EG'),
+       ('EG', 'EG'),
+       ('EGY', 'EG'),
+       ('EGYPT', 'EG'),
+       ('EH', 'EH'),
+       ('ESH', 'EH'),
+       ('WESTERN SAHARA', 'EH'),
+       ('ER', 'ER'),
+       ('ERI', 'ER'),
+       ('ERITREA', 'ER'),
+       ('THE STATE OF ERITREA', 'ER'),
+       ('ES', 'ES'),
+       ('ESP', 'ES'),
+       ('KINGDOM OF SPAIN', 'ES'),
+       ('SPAIN', 'ES'),
+       ('ET', 'ET'),
+       ('ETH', 'ET'),
+       ('ETHIOPIA', 'ET'),
+       ('FEDERAL DEMOCRATIC REPUBLIC OF ETHIOPIA', 'ET'),
+       ('FI', 'FI'),
+       ('FIN', 'FI'),
+       ('FINLAND', 'FI'),
+       ('REPUBLIC OF FINLAND', 'FI'),
+       ('FIJI', 'FJ'),
+       ('FJ', 'FJ'),
+       ('FJI', 'FJ'),
+       ('REPUBLIC OF FIJI', 'FJ'),
+       ('FALKLAND ISLANDS (MALVINAS)', 'FK'),
+       ('FK', 'FK'),
+       ('FLK', 'FK'),
+       ('FEDERATED STATES OF MICRONESIA', 'FM'),
+       ('FM', 'FM'),
+       ('FSM', 'FM'),
+       ('MICRONESIA', 'FM'),
+       ('MICRONESIA, FEDERATED STATES OF', 'FM'),
+       ('FAROE ISLANDS', 'FO'),
+       ('FO', 'FO'),
+       ('FRO', 'FO'),
+       ('FR', 'FR'),
+       ('FRA', 'FR'),
+       ('FRANCE', 'FR'),
+       ('FRENCH REPUBLIC', 'FR'),
+       ('GA', 'GA'),
+       ('GAB', 'GA'),
+       ('GABON', 'GA'),
+       ('GABONESE REPUBLIC', 'GA'),
+       ('BRITAIN', 'GB'),
+       ('ENGLAND', 'GB'),
+       ('GB', 'GB'),
+       ('GBR', 'GB'),
+       ('GREAT BRITAIN', 'GB'),
+       ('NORTHERN IRELAND', 'GB'),
+       ('SCOTLAND', 'GB'),
+       ('UK', 'GB'),
+       ('UNITED KINGDOM', 'GB'),
+       ('UNITED KINGDOM OF GREAT BRITAIN AND NORTHERN IRELAND', 'GB'),
+       ('WALES', 'GB'),
+       ('GD', 'GD'),
+       ('GRD', 'GD'),
+       ('GRENADA', 'GD'),
+       ('GE', 'GE'),
+       ('GEO', 'GE'),
+       ('GEORGIA', 'GE'),
+       ('FRENCH GUIANA', 'GF'),
+       ('GF', 'GF'),
+       ('GUF', 'GF'),
+       ('GG', 'GG'),
+       ('GGY', 'GG'),
+       ('GUERNSEY', 'GG'),
+       ('GH', 'GH'),
+       ('GHA', 'GH'),
+       ('GHANA', 'GH'),
+       ('REPUBLIC OF GHANA', 'GH'),
+       ('GI', 'GI'),
+       ('GIB', 'GI'),
+       ('GIBRALTAR', 'GI'),
+       ('GL', 'GL'),
+       ('GREENLAND', 'GL'),
+       ('GRL', 'GL'),
+       ('GAMBIA', 'GM'),
+       ('GM', 'GM'),
+       ('GMB', 'GM'),
+       ('REPUBLIC OF THE GAMBIA', 'GM'),
+       ('THE GAMBIA', 'GM'),
+       ('GIN', 'GN'),
+       ('GN', 'GN'),
+       ('GUINEA', 'GN'),
+       ('REPUBLIC OF GUINEA', 'GN'),
+       ('GLP', 'GP'),
+       ('GP', 'GP'),
+       ('GUADELOUPE', 'GP'),
+       ('EQUATORIAL GUINEA', 'GQ'),
+       ('GNQ', 'GQ'),
+       ('GQ', 'GQ'),
+       ('REPUBLIC OF EQUATORIAL GUINEA', 'GQ'),
+       ('GR', 'GR'),
+       ('GRC', 'GR'),
+       ('GREECE', 'GR'),
+       ('HELLENIC REPUBLIC', 'GR'),
+       ('GS', 'GS'),
+       ('SGS', 'GS'),
+       ('SOUTH GEORGIA AND THE SOUTH SANDWICH ISLANDS', 'GS'),
+       ('GT', 'GT'),
+       ('GTM', 'GT'),
+       ('GUATEMALA', 'GT'),
+       ('REPUBLIC OF GUATEMALA', 'GT'),
+       ('GU', 'GU'),
+       ('GUAM', 'GU'),
+       ('GUM', 'GU'),
+       ('GNB', 'GW'),
+       ('GUINEA-BISSAU', 'GW'),
+       ('GW', 'GW'),
+       ('REPUBLIC OF GUINEA-BISSAU', 'GW'),
+       ('GUY', 'GY'),
+       ('GUYANA', 'GY'),
+       ('GY', 'GY'),
+       ('REPUBLIC OF GUYANA', 'GY'),
+       ('HK', 'HK'),
+       ('HKG', 'HK'),
+       ('HONG KONG', 'HK'),
+       ('HONG KONG SPECIAL ADMINISTRATIVE REGION OF CHINA', 'HK'),
+       ('HEARD ISLAND AND MCDONALD ISLANDS', 'HM'),
+       ('HM', 'HM'),
+       ('HMD', 'HM'),
+       ('HN', 'HN'),
+       ('HND', 'HN'),
+       ('HONDURAS', 'HN'),
+       ('REPUBLIC OF HONDURAS', 'HN'),
+       ('CROATIA', 'HR'),
+       ('HR', 'HR'),
+       ('HRV', 'HR'),
+       ('REPUBLIC OF CROATIA', 'HR'),
+       ('HAITI', 'HT'),
+       ('HT', 'HT'),
+       ('HTI', 'HT'),
+       ('REPUBLIC OF HAITI', 'HT'),
+       ('HU', 'HU'),
+       ('HUN', 'HU'),
+       ('HUNGARY', 'HU'),
+       ('ID', 'ID'),
+       ('IDN', 'ID'),
+       ('INDONESIA', 'ID'),
+       ('REPUBLIC OF INDONESIA', 'ID'),
+       ('IE', 'IE'),
+       ('IRELAND', 'IE'),
+       ('IRL', 'IE'),
+       ('IL', 'IL'),
+       ('ISR', 'IL'),
+       ('ISRAEL', 'IL'),
+       ('STATE OF ISRAEL', 'IL'),
+       ('IM', 'IM'),
+       ('IMN', 'IM'),
+       ('ISLE OF MAN', 'IM'),
+       ('IN', 'IN'),
+       ('IND', 'IN'),
+       ('INDIA', 'IN'),
+       ('REPUBLIC OF INDIA', 'IN'),
+       ('BRITISH INDIAN OCEAN TERRITORY', 'IO'),
+       ('IO', 'IO'),
+       ('IOT', 'IO'),
+       ('IQ', 'IQ'),
+       ('IRAQ', 'IQ'),
+       ('IRQ', 'IQ'),
+       ('REPUBLIC OF IRAQ', 'IQ'),
+       ('IR', 'IR'),
+       ('IRAN', 'IR'),
+       ('IRAN, ISLAMIC REPUBLIC OF', 'IR'),
+       ('IRN', 'IR'),
+       ('ISLAMIC REPUBLIC OF IRAN', 'IR'),
+       ('ICELAND', 'IS'),
+       ('IS', 'IS'),
+       ('ISL', 'IS'),
+       ('REPUBLIC OF ICELAND', 'IS'),
+       ('IT', 'IT'),
+       ('ITA', 'IT'),
+       ('ITALIAN REPUBLIC', 'IT'),
+       ('ITALY', 'IT'),
+       ('JE', 'JE'),
+       ('JERSEY', 'JE'),
+       ('JEY', 'JE'),
+       ('JAM', 'JM'),
+       ('JAMAICA', 'JM'),
+       ('JM', 'JM'),
+       ('HASHEMITE KINGDOM OF JORDAN', 'JO'),
+       ('JO', 'JO'),
+       ('JOR', 'JO'),
+       ('JORDAN', 'JO'),
+       ('JAPAN', 'JP'),
+       ('JP', 'JP'),
+       ('JPN', 'JP'),
+       ('KE', 'KE'),
+       ('KEN', 'KE'),
+       ('KENYA', 'KE'),
+       ('REPUBLIC OF KENYA', 'KE'),
+       ('KG', 'KG'),
+       ('KGZ', 'KG'),
+       ('KYRGYZ REPUBLIC', 'KG'),
+       ('KYRGYZSTAN', 'KG'),
+       ('CAMBODIA', 'KH'),
+       ('KH', 'KH'),
+       ('KHM', 'KH'),
+       ('KINGDOM OF CAMBODIA', 'KH'),
+       ('KI', 'KI'),
+       ('KIR', 'KI'),
+       ('KIRIBATI', 'KI'),
+       ('REPUBLIC OF KIRIBATI', 'KI'),
+       ('COM', 'KM'),
+       ('COMOROS', 'KM'),
+       ('KM', 'KM'),
+       ('UNION OF THE COMOROS', 'KM'),
+       ('KN', 'KN'),
+       ('KNA', 'KN'),
+       ('SAINT KITTS AND NEVIS', 'KN'),
+       ('DEMOCRATIC PEOPLE''S REPUBLIC OF KOREA', 'KP'),
+       ('KOREA, DEMOCRATIC PEOPLE''S REPUBLIC OF', 'KP'),
+       ('KP', 'KP'),
+       ('NORTH KOREA', 'KP'),
+       ('PRK', 'KP'),
+       ('KOR', 'KR'),
+       ('KOREA', 'KR'),
+       ('KOREA, REPUBLIC OF', 'KR'),
+       ('KR', 'KR'),
+       ('REPUBLIC OF KOREA', 'KR'),
+       ('SOUTH KOREA', 'KR'),
+       ('KUWAIT', 'KW'),
+       ('KW', 'KW'),
+       ('KWT', 'KW'),
+       ('STATE OF KUWAIT', 'KW'),
+       ('CAYMAN ISLANDS', 'KY'),
+       ('CYM', 'KY'),
+       ('KY', 'KY'),
+       ('KAZ', 'KZ'),
+       ('KAZAKHSTAN', 'KZ'),
+       ('KZ', 'KZ'),
+       ('REPUBLIC OF KAZAKHSTAN', 'KZ'),
+       ('LA', 'LA'),
+       ('LAO', 'LA'),
+       ('LAO PEOPLE''S DEMOCRATIC REPUBLIC', 'LA'),
+       ('LAOS', 'LA'),
+       ('LB', 'LB'),
+       ('LBN', 'LB'),
+       ('LEBANESE REPUBLIC', 'LB'),
+       ('LEBANON', 'LB'),
+       ('LC', 'LC'),
+       ('LCA', 'LC'),
+       ('SAINT LUCIA', 'LC'),
+       ('LI', 'LI'),
+       ('LIE', 'LI'),
+       ('LIECHTENSTEIN', 'LI'),
+       ('PRINCIPALITY OF LIECHTENSTEIN', 'LI'),
+       ('DEMOCRATIC SOCIALIST REPUBLIC OF SRI LANKA', 'LK'),
+       ('LK', 'LK'),
+       ('LKA', 'LK'),
+       ('SRI LANKA', 'LK'),
+       ('LBR', 'LR'),
+       ('LIBERIA', 'LR'),
+       ('LR', 'LR'),
+       ('REPUBLIC OF LIBERIA', 'LR'),
+       ('KINGDOM OF LESOTHO', 'LS'),
+       ('LESOTHO', 'LS'),
+       ('LS', 'LS'),
+       ('LSO', 'LS'),
+       ('LITHUANIA', 'LT'),
+       ('LT', 'LT'),
+       ('LTU', 'LT'),
+       ('REPUBLIC OF LITHUANIA', 'LT'),
+       ('GRAND DUCHY OF LUXEMBOURG', 'LU'),
+       ('LU', 'LU'),
+       ('LUX', 'LU'),
+       ('LUXEMBOURG', 'LU'),
+       ('LATVIA', 'LV'),
+       ('LV', 'LV'),
+       ('LVA', 'LV'),
+       ('REPUBLIC OF LATVIA', 'LV'),
+       ('LBY', 'LY'),
+       ('LIBYA', 'LY'),
+       ('LY', 'LY'),
+       ('KINGDOM OF MOROCCO', 'MA'),
+       ('MA', 'MA'),
+       ('MAR', 'MA'),
+       ('MOROCCO', 'MA'),
+       ('MC', 'MC'),
+       ('MCO', 'MC'),
+       ('MONACO', 'MC'),
+       ('PRINCIPALITY OF MONACO', 'MC'),
+       ('MD', 'MD'),
+       ('MDA', 'MD'),
+       ('MOLDOVA', 'MD'),
+       ('MOLDOVA, REPUBLIC OF', 'MD'),
+       ('REPUBLIC OF MOLDOVA', 'MD'),
+       ('ME', 'ME'),
+       ('MNE', 'ME'),
+       ('MONTENEGRO', 'ME'),
+       ('MAF', 'MF'),
+       ('MF', 'MF'),
+       ('SAINT MARTIN (FRENCH PART)', 'MF'),
+       ('MADAGASCAR', 'MG'),
+       ('MDG', 'MG'),
+       ('MG', 'MG'),
+       ('REPUBLIC OF MADAGASCAR', 'MG'),
+       ('MARSHALL ISLANDS', 'MH'),
+       ('MH', 'MH'),
+       ('MHL', 'MH'),
+       ('REPUBLIC OF THE MARSHALL ISLANDS', 'MH'),
+       ('MACEDONIA', 'MK'),
+       ('MK', 'MK'),
+       ('MKD', 'MK'),
+       ('NORTH MACEDONIA', 'MK'),
+       ('REPUBLIC OF NORTH MACEDONIA', 'MK'),
+       ('MALI', 'ML'),
+       ('ML', 'ML'),
+       ('MLI', 'ML'),
+       ('REPUBLIC OF MALI', 'ML'),
+       ('BURMA', 'MM'),
+       ('MM', 'MM'),
+       ('MMR', 'MM'),
+       ('MYANMAR', 'MM'),
+       ('REPUBLIC OF MYANMAR', 'MM'),
+       ('MN', 'MN'),
+       ('MNG', 'MN'),
+       ('MONGOLIA', 'MN'),
+       ('MAC', 'MO'),
+       ('MACAO', 'MO'),
+       ('MACAO SPECIAL ADMINISTRATIVE REGION OF CHINA', 'MO'),
+       ('MO', 'MO'),
+       ('COMMONWEALTH OF THE NORTHERN MARIANA ISLANDS', 'MP'),
+       ('MNP', 'MP'),
+       ('MP', 'MP'),
+       ('NORTHERN MARIANA ISLANDS', 'MP'),
+       ('MARTINIQUE', 'MQ'),
+       ('MQ', 'MQ'),
+       ('MTQ', 'MQ'),
+       ('ISLAMIC REPUBLIC OF MAURITANIA', 'MR'),
+       ('MAURITANIA', 'MR'),
+       ('MR', 'MR'),
+       ('MRT', 'MR'),
+       ('MONTSERRAT', 'MS'),
+       ('MS', 'MS'),
+       ('MSR', 'MS'),
+       ('MALTA', 'MT'),
+       ('MLT', 'MT'),
+       ('MT', 'MT'),
+       ('REPUBLIC OF MALTA', 'MT'),
+       ('MAURITIUS', 'MU'),
+       ('MU', 'MU'),
+       ('MUS', 'MU'),
+       ('REPUBLIC OF MAURITIUS', 'MU'),
+       ('MALDIVES', 'MV'),
+       ('MDV', 'MV'),
+       ('MV', 'MV'),
+       ('REPUBLIC OF MALDIVES', 'MV'),
+       ('MALAWI', 'MW'),
+       ('MW', 'MW'),
+       ('MWI', 'MW'),
+       ('REPUBLIC OF MALAWI', 'MW'),
+       ('MEX', 'MX'),
+       ('MEXICO', 'MX'),
+       ('MX', 'MX'),
+       ('UNITED MEXICAN STATES', 'MX'),
+       ('MALAYSIA', 'MY'),
+       ('MY', 'MY'),
+       ('MYS', 'MY'),
+       ('MOZ', 'MZ'),
+       ('MOZAMBIQUE', 'MZ'),
+       ('MZ', 'MZ'),
+       ('REPUBLIC OF MOZAMBIQUE', 'MZ'),
+       ('NA', 'NA'),
+       ('NAM', 'NA'),
+       ('NAMIBIA', 'NA'),
+       ('REPUBLIC OF NAMIBIA', 'NA'),
+       ('NC', 'NC'),
+       ('NCL', 'NC'),
+       ('NEW CALEDONIA', 'NC'),
+       ('NE', 'NE'),
+       ('NER', 'NE'),
+       ('NIGER', 'NE'),
+       ('REPUBLIC OF THE NIGER', 'NE'),
+       ('NF', 'NF'),
+       ('NFK', 'NF'),
+       ('NORFOLK ISLAND', 'NF'),
+       ('FEDERAL REPUBLIC OF NIGERIA', 'NG'),
+       ('NG', 'NG'),
+       ('NGA', 'NG'),
+       ('NIGERIA', 'NG'),
+       ('NI', 'NI'),
+       ('NIC', 'NI'),
+       ('NICARAGUA', 'NI'),
+       ('REPUBLIC OF NICARAGUA', 'NI'),
+       ('HOLLAND', 'NL'),
+       ('KINGDOM OF THE NETHERLANDS', 'NL'),
+       ('NETHERLANDS', 'NL'),
+       ('NL', 'NL'),
+       ('NLD', 'NL'),
+       ('THE NETHERLANDS', 'NL'),
+       ('KINGDOM OF NORWAY', 'NO'),
+       ('NO', 'NO'),
+       ('NOR', 'NO'),
+       ('NORWAY', 'NO'),
+       ('FEDERAL DEMOCRATIC REPUBLIC OF NEPAL', 'NP'),
+       ('NEPAL', 'NP'),
+       ('NP', 'NP'),
+       ('NPL', 'NP'),
+       ('NAURU', 'NR'),
+       ('NR', 'NR'),
+       ('NRU', 'NR'),
+       ('REPUBLIC OF NAURU', 'NR'),
+       ('NIU', 'NU'),
+       ('NIUE', 'NU'),
+       ('NU', 'NU'),
+       ('NEW ZEALAND', 'NZ'),
+       ('NZ', 'NZ'),
+       ('NZL', 'NZ'),
+       ('OM', 'OM'),
+       ('OMAN', 'OM'),
+       ('OMN', 'OM'),
+       ('SULTANATE OF OMAN', 'OM'),
+       ('PA', 'PA'),
+       ('PAN', 'PA'),
+       ('PANAMA', 'PA'),
+       ('REPUBLIC OF PANAMA', 'PA'),
+       ('PE', 'PE'),
+       ('PER', 'PE'),
+       ('PERU', 'PE'),
+       ('REPUBLIC OF PERU', 'PE'),
+       ('FRENCH POLYNESIA', 'PF'),
+       ('PF', 'PF'),
+       ('PYF', 'PF'),
+       ('INDEPENDENT STATE OF PAPUA NEW GUINEA', 'PG'),
+       ('PAPUA NEW GUINEA', 'PG'),
+       ('PG', 'PG'),
+       ('PNG', 'PG'),
+       ('PH', 'PH'),
+       ('PHILIPPINES', 'PH'),
+       ('PHL', 'PH'),
+       ('REPUBLIC OF THE PHILIPPINES', 'PH'),
+       ('ISLAMIC REPUBLIC OF PAKISTAN', 'PK'),
+       ('PAK', 'PK'),
+       ('PAKISTAN', 'PK'),
+       ('PK', 'PK'),
+       ('PL', 'PL'),
+       ('POL', 'PL'),
+       ('POLAND', 'PL'),
+       ('REPUBLIC OF POLAND', 'PL'),
+       ('PM', 'PM'),
+       ('SAINT PIERRE AND MIQUELON', 'PM'),
+       ('SPM', 'PM'),
+       ('PCN', 'PN'),
+       ('PITCAIRN', 'PN'),
+       ('PN', 'PN'),
+       ('PR', 'PR'),
+       ('PRI', 'PR'),
+       ('PUERTO RICO', 'PR'),
+       ('PALESTINE', 'PS'),
+       ('PALESTINE, STATE OF', 'PS'),
+       ('PS', 'PS'),
+       ('PSE', 'PS'),
+       ('THE STATE OF PALESTINE', 'PS'),
+       ('PORTUGAL', 'PT'),
+       ('PORTUGUESE REPUBLIC', 'PT'),
+       ('PRT', 'PT'),
+       ('PT', 'PT'),
+       ('PALAU', 'PW'),
+       ('PLW', 'PW'),
+       ('PW', 'PW'),
+       ('REPUBLIC OF PALAU', 'PW'),
+       ('PARAGUAY', 'PY'),
+       ('PRY', 'PY'),
+       ('PY', 'PY'),
+       ('REPUBLIC OF PARAGUAY', 'PY'),
+       ('QA', 'QA'),
+       ('QAT', 'QA'),
+       ('QATAR', 'QA'),
+       ('STATE OF QATAR', 'QA'),
+       ('RE', 'RE'),
+       ('REU', 'RE'),
+       ('REUNION', 'RE'),
+       ('RÉUNION', 'RE'),
+       ('RO', 'RO'),
+       ('ROMANIA', 'RO'),
+       ('ROU', 'RO'),
+       ('REPUBLIC OF SERBIA', 'RS'),
+       ('RS', 'RS'),
+       ('SERBIA', 'RS'),
+       ('SRB', 'RS'),
+       ('RU', 'RU'),
+       ('RUS', 'RU'),
+       ('RUSSIA', 'RU'),
+       ('RUSSIAN FEDERATION', 'RU'),
+       ('RW', 'RW'),
+       ('RWA', 'RW'),
+       ('RWANDA', 'RW'),
+       ('RWANDESE REPUBLIC', 'RW'),
+       ('KINGDOM OF SAUDI ARABIA', 'SA'),
+       ('SA', 'SA'),
+       ('SAU', 'SA'),
+       ('SAUDI ARABIA', 'SA'),
+       ('SB', 'SB'),
+       ('SLB', 'SB'),
+       ('SOLOMON ISLANDS', 'SB'),
+       ('REPUBLIC OF SEYCHELLES', 'SC'),
+       ('SC', 'SC'),
+       ('SEYCHELLES', 'SC'),
+       ('SYC', 'SC'),
+       ('REPUBLIC OF THE SUDAN', 'SD'),
+       ('SD', 'SD'),
+       ('SDN', 'SD'),
+       ('SUDAN', 'SD'),
+       ('KINGDOM OF SWEDEN', 'SE'),
+       ('SE', 'SE'),
+       ('SWE', 'SE'),
+       ('SWEDEN', 'SE'),
+       ('REPUBLIC OF SINGAPORE', 'SG'),
+       ('SG', 'SG'),
+       ('SGP', 'SG'),
+       ('SINGAPORE', 'SG'),
+       ('SAINT HELENA, ASCENSION AND TRISTAN DA CUNHA', 'SH'),
+       ('SH', 'SH'),
+       ('SHN', 'SH'),
+       ('REPUBLIC OF SLOVENIA', 'SI'),
+       ('SI', 'SI'),
+       ('SLOVENIA', 'SI'),
+       ('SVN', 'SI'),
+       ('SJ', 'SJ'),
+       ('SJM', 'SJ'),
+       ('SVALBARD AND JAN MAYEN', 'SJ'),
+       ('SK', 'SK'),
+       ('SLOVAK REPUBLIC', 'SK'),
+       ('SLOVAKIA', 'SK'),
+       ('SVK', 'SK'),
+       ('REPUBLIC OF SIERRA LEONE', 'SL'),
+       ('SIERRA LEONE', 'SL'),
+       ('SL', 'SL'),
+       ('SLE', 'SL'),
+       ('REPUBLIC OF SAN MARINO', 'SM'),
+       ('SAN MARINO', 'SM'),
+       ('SM', 'SM'),
+       ('SMR', 'SM'),
+       ('REPUBLIC OF SENEGAL', 'SN'),
+       ('SEN', 'SN'),
+       ('SENEGAL', 'SN'),
+       ('SN', 'SN'),
+       ('FEDERAL REPUBLIC OF SOMALIA', 'SO'),
+       ('SO', 'SO'),
+       ('SOM', 'SO'),
+       ('SOMALIA', 'SO'),
+       ('REPUBLIC OF SURINAME', 'SR'),
+       ('SR', 'SR'),
+       ('SUR', 'SR'),
+       ('SURINAME', 'SR'),
+       ('REPUBLIC OF SOUTH SUDAN', 'SS'),
+       ('SOUTH SUDAN', 'SS'),
+       ('SS', 'SS'),
+       ('SSD', 'SS'),
+       ('DEMOCRATIC REPUBLIC OF SAO TOME AND PRINCIPE', 'ST'),
+       ('SAO TOME AND PRINCIPE', 'ST'),
+       ('ST', 'ST'),
+       ('STP', 'ST'),
+       ('EL SALVADOR', 'SV'),
+       ('REPUBLIC OF EL SALVADOR', 'SV'),
+       ('SLV', 'SV'),
+       ('SV', 'SV'),
+       ('SINT MAARTEN (DUTCH PART)', 'SX'),
+       ('SX', 'SX'),
+       ('SXM', 'SX'),
+       ('SY', 'SY'),
+       ('SYR', 'SY'),
+       ('SYRIA', 'SY'),
+       ('SYRIAN ARAB REPUBLIC', 'SY'),
+       ('ESWATINI', 'SZ'),
+       ('KINGDOM OF ESWATINI', 'SZ'),
+       ('SWAZILAND', 'SZ'),
+       ('SWZ', 'SZ'),
+       ('SZ', 'SZ'),
+       ('TC', 'TC'),
+       ('TCA', 'TC'),
+       ('TURKS AND CAICOS ISLANDS', 'TC'),
+       ('CHAD', 'TD'),
+       ('REPUBLIC OF CHAD', 'TD'),
+       ('TCD', 'TD'),
+       ('TD', 'TD'),
+       ('ATF', 'TF'),
+       ('FRENCH SOUTHERN TERRITORIES', 'TF'),
+       ('TF', 'TF'),
+       ('TG', 'TG'),
+       ('TGO', 'TG'),
+       ('TOGO', 'TG'),
+       ('TOGOLESE REPUBLIC', 'TG'),
+       ('KINGDOM OF THAILAND', 'TH'),
+       ('TH', 'TH'),
+       ('THA', 'TH'),
+       ('THAILAND', 'TH'),
+       ('REPUBLIC OF TAJIKISTAN', 'TJ'),
+       ('TAJIKISTAN', 'TJ'),
+       ('TJ', 'TJ'),
+       ('TJK', 'TJ'),
+       ('TK', 'TK'),
+       ('TKL', 'TK'),
+       ('TOKELAU', 'TK'),
+       ('DEMOCRATIC REPUBLIC OF TIMOR-LESTE', 'TL'),
+       ('EAST TIMOR', 'TL'),
+       ('TIMOR-LESTE', 'TL'),
+       ('TL', 'TL'),
+       ('TLS', 'TL'),
+       ('TKM', 'TM'),
+       ('TM', 'TM'),
+       ('TURKMENISTAN', 'TM'),
+       ('REPUBLIC OF TUNISIA', 'TN'),
+       ('TN', 'TN'),
+       ('TUN', 'TN'),
+       ('TUNISIA', 'TN'),
+       ('KINGDOM OF TONGA', 'TO'),
+       ('TO', 'TO'),
+       ('TON', 'TO'),
+       ('TONGA', 'TO'),
+       ('REPUBLIC OF TURKIYE', 'TR'),
+       ('REPUBLIC OF TÜRKIYE', 'TR'),
+       ('TR', 'TR'),
+       ('TUR', 'TR'),
+       ('TURKEY', 'TR'),
+       ('TURKIYE', 'TR'),
+       ('TÜRKIYE', 'TR'),
+       ('REPUBLIC OF TRINIDAD AND TOBAGO', 'TT'),
+       ('TRINIDAD AND TOBAGO', 'TT'),
+       ('TT', 'TT'),
+       ('TTO', 'TT'),
+       ('TUV', 'TV'),
+       ('TUVALU', 'TV'),
+       ('TV', 'TV'),
+       ('TAIWAN', 'TW'),
+       ('TAIWAN, PROVINCE OF CHINA', 'TW'),
+       ('TW', 'TW'),
+       ('TWN', 'TW'),
+       ('TANZANIA', 'TZ'),
+       ('TANZANIA, UNITED REPUBLIC OF', 'TZ'),
+       ('TZ', 'TZ'),
+       ('TZA', 'TZ'),
+       ('UNITED REPUBLIC OF TANZANIA', 'TZ'),
+       ('UA', 'UA'),
+       ('UKR', 'UA'),
+       ('UKRAINE', 'UA'),
+       ('REPUBLIC OF UGANDA', 'UG'),
+       ('UG', 'UG'),
+       ('UGA', 'UG'),
+       ('UGANDA', 'UG'),
+       ('UM', 'UM'),
+       ('UMI', 'UM'),
+       ('UNITED STATES MINOR OUTLYING ISLANDS', 'UM'),
+       ('AMERICA', 'US'),
+       ('UNITED STATES', 'US'),
+       ('UNITED STATES OF AMERICA', 'US'),
+       ('US', 'US'),
+       ('USA', 'US'),
+       ('EASTERN REPUBLIC OF URUGUAY', 'UY'),
+       ('URUGUAY', 'UY'),
+       ('URY', 'UY'),
+       ('UY', 'UY'),
+       ('REPUBLIC OF UZBEKISTAN', 'UZ'),
+       ('UZ', 'UZ'),
+       ('UZB', 'UZ'),
+       ('UZBEKISTAN', 'UZ'),
+       ('HOLY SEE (VATICAN CITY STATE)', 'VA'),
+       ('VA', 'VA'),
+       ('VAT', 'VA'),
+       ('VATICAN', 'VA'),
+       ('VATICAN CITY', 'VA'),
+       ('SAINT VINCENT AND THE GRENADINES', 'VC'),
+       ('VC', 'VC'),
+       ('VCT', 'VC'),
+       ('BOLIVARIAN REPUBLIC OF VENEZUELA', 'VE'),
+       ('VE', 'VE'),
+       ('VEN', 'VE'),
+       ('VENEZUELA', 'VE'),
+       ('VENEZUELA, BOLIVARIAN REPUBLIC OF', 'VE'),
+       ('BRITISH VIRGIN ISLANDS', 'VG'),
+       ('VG', 'VG'),
+       ('VGB', 'VG'),
+       ('VIRGIN ISLANDS, BRITISH', 'VG'),
+       ('VI', 'VI'),
+       ('VIR', 'VI'),
+       ('VIRGIN ISLANDS OF THE UNITED STATES', 'VI'),
+       ('VIRGIN ISLANDS, US', 'VI'),
+       ('SOCIALIST REPUBLIC OF VIET NAM', 'VN'),
+       ('VIET NAM', 'VN'),
+       ('VIETNAM', 'VN'),
+       ('VN', 'VN'),
+       ('VNM', 'VN'),
+       ('REPUBLIC OF VANUATU', 'VU'),
+       ('VANUATU', 'VU'),
+       ('VU', 'VU'),
+       ('VUT', 'VU'),
+       ('WALLIS AND FUTUNA', 'WF'),
+       ('WF', 'WF'),
+       ('WLF', 'WF'),
+       ('INDEPENDENT STATE OF SAMOA', 'WS'),
+       ('SAMOA', 'WS'),
+       ('WS', 'WS'),
+       ('WSM', 'WS'),
+       ('REPUBLIC OF YEMEN', 'YE'),
+       ('YE', 'YE'),
+       ('YEM', 'YE'),
+       ('YEMEN', 'YE'),
+       ('MAYOTTE', 'YT'),
+       ('MYT', 'YT'),
+       ('YT', 'YT'),
+       ('REPUBLIC OF SOUTH AFRICA', 'ZA'),
+       ('SOUTH AFRICA', 'ZA'),
+       ('ZA', 'ZA'),
+       ('ZAF', 'ZA'),
+       ('REPUBLIC OF ZAMBIA', 'ZM'),
+       ('ZAMBIA', 'ZM'),
+       ('ZM', 'ZM'),
+       ('ZMB', 'ZM'),
+       ('REPUBLIC OF ZIMBABWE', 'ZW'),
+       ('ZIMBABWE', 'ZW'),
+       ('ZW', 'ZW'),
+       ('ZWE', 'ZW');
+
+CREATE PROCEDURE check_target_countries()
+BEGIN
+    DECLARE unmatched INT;
+    SELECT COUNT(*)
+    INTO unmatched
+    FROM targets t
+             LEFT JOIN country_aliases a
+                       ON a.alias = UPPER(TRIM(REGEXP_REPLACE(REPLACE(t.country, '.', ''), '[[:space:]]+', ' '))) COLLATE utf8mb4_bin
+    WHERE a.alias IS NULL;
+    IF unmatched > 0 THEN
+        DROP TABLE country_aliases;
+        SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'Some targets have a country that is not in the ISO 3166 dataset; fix them and run the migration again';
+    END IF;
+END;
+
+CALL check_target_countries();
+DROP PROCEDURE check_target_countries;
+
+UPDATE targets t
+    JOIN country_aliases a
+    ON a.alias = UPPER(TRIM(REGEXP_REPLACE(REPLACE(t.country, '.', ''), '[[:space:]]+', ' '))) COLLATE utf8mb4_bin
+SET t.country = a.code;
+
+DROP TABLE country_aliases;
\ No newline at end of file
@@ -0,0 +1,5 @@
+ALTER TABLE targets
+    DROP INDEX idx_targets_location,
+    DROP COLUMN latitude,
+    DROP COLUMN longitude,
+    DROP COLUMN last_seen_at;
\ No newline at end of file
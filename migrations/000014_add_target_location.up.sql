@@ -0,0 +1,5 @@
+ALTER TABLE targets
+    ADD COLUMN latitude     DECIMAL(9, 6) NULL AFTER country,
+    ADD COLUMN longitude    DECIMAL(9, 6) NULL AFTER latitude,
+    ADD COLUMN last_seen_at DATETIME      NULL AFTER longitude,
+    ADD INDEX idx_targets_location (latitude, longitude);
\ No newline at end of file
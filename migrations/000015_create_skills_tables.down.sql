@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS target_skills;
+DROP TABLE IF EXISTS cat_skills;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS cat_skills
+(
+    cat_id CHAR(36)    NOT NULL,
+    skill  VARCHAR(32) NOT NULL,
+    PRIMARY KEY (cat_id, skill),
+    INDEX idx_cat_skills_skill (skill),
+    FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS target_skills
+(
+    target_id CHAR(36)    NOT NULL,
+    skill     VARCHAR(32) NOT NULL,
+    PRIMARY KEY (target_id, skill),
+    FOREIGN KEY (target_id) REFERENCES targets (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS mission_templates
+(
+    id              CHAR(36)       NOT NULL,
+    name            VARCHAR(64)    NOT NULL,
+    priority        TINYINT        NOT NULL DEFAULT 3,
+    budget          DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    budget_currency CHAR(3)        NOT NULL DEFAULT 'USD',
+    created_at      DATETIME       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE INDEX idx_mission_templates_name (name)
+);
+
+CREATE TABLE IF NOT EXISTS mission_template_targets
+(
+    id              CHAR(36)     NOT NULL,
+    template_id     CHAR(36)     NOT NULL,
+    position        INT          NOT NULL,
+    name            VARCHAR(32)  NOT NULL,
+    country         CHAR(2)      NOT NULL,
+    notes           VARCHAR(255) NOT NULL,
+    required_skills JSON         NOT NULL,
+    PRIMARY KEY (id),
+    INDEX idx_mission_template_targets_position (template_id, position),
+    FOREIGN KEY (template_id) REFERENCES mission_templates (id) ON DELETE CASCADE
+);
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS attachments
+(
+    id           CHAR(36)     NOT NULL,
+    owner_type   VARCHAR(16)  NOT NULL,
+    owner_id     CHAR(36)     NOT NULL,
+    filename     VARCHAR(255) NOT NULL,
+    content_type VARCHAR(127) NOT NULL,
+    size         BIGINT       NOT NULL,
+    checksum     CHAR(64)     NOT NULL,
+    storage_key  VARCHAR(255) NOT NULL,
+    uploaded_by  VARCHAR(64)  NULL,
+    created_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX idx_attachments_owner (owner_type, owner_id, created_at)
+);
\ No newline at end of file
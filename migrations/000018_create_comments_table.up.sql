@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS comments
+(
+    id         CHAR(36)    NOT NULL,
+    owner_type VARCHAR(16) NOT NULL,
+    owner_id   CHAR(36)    NOT NULL,
+    parent_id  CHAR(36)    NULL,
+    author     VARCHAR(64) NOT NULL,
+    body       TEXT        NOT NULL,
+    mentions   JSON        NOT NULL,
+    created_at DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    edited_at  DATETIME    NULL,
+    PRIMARY KEY (id),
+    INDEX idx_comments_owner (owner_type, owner_id, created_at),
+    FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE
+);
\ No newline at end of file
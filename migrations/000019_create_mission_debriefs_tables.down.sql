@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS mission_debrief_outcomes;
+DROP TABLE IF EXISTS mission_debriefs;
\ No newline at end of file
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS mission_debriefs
+(
+    mission_id      CHAR(36)    NOT NULL,
+    cat_id          CHAR(36)    NULL,
+    cat_rating      TINYINT     NOT NULL,
+    lessons_learned TEXT        NOT NULL,
+    author          VARCHAR(64) NOT NULL,
+    created_at      DATETIME    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME    NULL,
+    PRIMARY KEY (mission_id),
+    FOREIGN KEY (mission_id) REFERENCES missions (id) ON DELETE CASCADE,
+    FOREIGN KEY (cat_id) REFERENCES cats (id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS mission_debrief_outcomes
+(
+    mission_id CHAR(36)                              NOT NULL,
+    target_id  CHAR(36)                              NOT NULL,
+    outcome    ENUM ('achieved', 'partial', 'missed') NOT NULL,
+    notes      VARCHAR(255)                          NOT NULL DEFAULT '',
+    PRIMARY KEY (mission_id, target_id),
+    FOREIGN KEY (mission_id) REFERENCES mission_debriefs (mission_id) ON DELETE CASCADE,
+    FOREIGN KEY (target_id) REFERENCES targets (id) ON DELETE CASCADE
+);
\ No newline at end of file
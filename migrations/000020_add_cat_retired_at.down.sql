@@ -0,0 +1,2 @@
+ALTER TABLE cats
+    DROP COLUMN retired_at;
\ No newline at end of file
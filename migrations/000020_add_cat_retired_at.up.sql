@@ -0,0 +1,8 @@
+ALTER TABLE cats
+    ADD COLUMN retired_at DATETIME NULL AFTER status;
+
+-- The real retirement date of cats retired before this migration is unknown, so it is backfilled
+-- as the time this migration runs; payroll keeps paying them up to that point.
+UPDATE cats
+SET retired_at = UTC_TIMESTAMP()
+WHERE status = 'retired';
\ No newline at end of file
@@ -0,0 +1,16 @@
+package blob
+
+import (
+	"context"
+	"io"
+
+	"sca/pkg/errors"
+)
+
+var ErrNotFound = errors.ErrNotFound{Msg: "Blob not found"}
+
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
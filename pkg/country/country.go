@@ -0,0 +1,88 @@
+package country
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ./gen -o ../../migrations/000013_normalise_target_countries.up.sql
+
+//go:embed iso3166.csv
+var dataset string
+
+type Country struct {
+	Alpha2  string `json:"alpha2"`
+	Alpha3  string `json:"alpha3"`
+	Numeric string `json:"numeric"`
+	Name    string `json:"name"`
+}
+
+var (
+	loadOnce  sync.Once
+	countries []Country
+	index     map[string]*Country
+)
+
+func load() {
+	records, err := csv.NewReader(strings.NewReader(dataset)).ReadAll()
+	if err != nil {
+		panic("country: invalid embedded dataset: " + err.Error())
+	}
+
+	countries = make([]Country, 0, len(records)-1)
+	for _, r := range records[1:] {
+		countries = append(countries, Country{Alpha2: r[0], Alpha3: r[1], Numeric: r[2], Name: r[3]})
+	}
+
+	index = make(map[string]*Country, len(countries)*4)
+	for i, r := range records[1:] {
+		c := &countries[i]
+		keys := []string{c.Alpha2, c.Alpha3, c.Name}
+		if r[4] != "" {
+			keys = append(keys, strings.Split(r[4], ";")...)
+		}
+		for _, k := range keys {
+			if _, ok := index[key(k)]; !ok {
+				index[key(k)] = c
+			}
+		}
+	}
+}
+
+func key(s string) string {
+	s = strings.ReplaceAll(s, ".", "")
+	return strings.ToUpper(strings.Join(strings.Fields(s), " "))
+}
+
+func Lookup(s string) (Country, bool) {
+	loadOnce.Do(load)
+	c, ok := index[key(s)]
+	if !ok {
+		return Country{}, false
+	}
+	return *c, true
+}
+
+func Normalize(s string) (string, bool) {
+	c, ok := Lookup(s)
+	if !ok {
+		return "", false
+	}
+	return c.Alpha2, true
+}
+
+func All() []Country {
+	loadOnce.Do(load)
+	return append([]Country(nil), countries...)
+}
+
+func Aliases() map[string]string {
+	loadOnce.Do(load)
+	aliases := make(map[string]string, len(index))
+	for k, c := range index {
+		aliases[k] = c.Alpha2
+	}
+	return aliases
+}
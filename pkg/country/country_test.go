@@ -0,0 +1,101 @@
+package country
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		wantOk bool
+	}{
+		{in: "DE", want: "DE", wantOk: true},
+		{in: "de", want: "DE", wantOk: true},
+		{in: "DEU", want: "DE", wantOk: true},
+		{in: "Germany", want: "DE", wantOk: true},
+		{in: "  germany ", want: "DE", wantOk: true},
+		{in: "United  Arab\tEmirates", want: "AE", wantOk: true},
+		{in: "UAE", want: "AE", wantOk: true},
+		{in: "U.A.E.", want: "AE", wantOk: true},
+		{in: "Côte d'Ivoire", want: "CI", wantOk: true},
+		{in: "People's Republic of Bangladesh", want: "BD", wantOk: true},
+		{in: "", wantOk: false},
+		{in: "Atlantis", wantOk: false},
+		{in: "XX", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := Normalize(tt.in)
+			if ok != tt.wantOk || got != tt.want {
+				t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	c, ok := Lookup("fra")
+	if !ok {
+		t.Fatal("Lookup(\"fra\") not found")
+	}
+	want := Country{Alpha2: "FR", Alpha3: "FRA", Numeric: "250", Name: "France"}
+	if c != want {
+		t.Errorf("Lookup(\"fra\") = %+v, want %+v", c, want)
+	}
+}
+
+func TestAll(t *testing.T) {
+	all := All()
+	if len(all) != 249 {
+		t.Errorf("All() returned %d countries, want 249", len(all))
+	}
+
+	alpha2, alpha3 := map[string]bool{}, map[string]bool{}
+	for _, c := range all {
+		if len(c.Alpha2) != 2 || len(c.Alpha3) != 3 || len(c.Numeric) != 3 || c.Name == "" {
+			t.Errorf("malformed country %+v", c)
+		}
+		if alpha2[c.Alpha2] || alpha3[c.Alpha3] {
+			t.Errorf("duplicate country code in %+v", c)
+		}
+		alpha2[c.Alpha2], alpha3[c.Alpha3] = true, true
+
+		for _, k := range []string{c.Alpha2, c.Alpha3, c.Name} {
+			if got, _ := Normalize(k); got != c.Alpha2 {
+				t.Errorf("Normalize(%q) = %q, want %q", k, got, c.Alpha2)
+			}
+		}
+	}
+
+	all[0].Name = "changed"
+	if All()[0].Name == "changed" {
+		t.Error("All() exposes the internal slice")
+	}
+}
+
+func TestMigrationMatchesDataset(t *testing.T) {
+	data, err := os.ReadFile("../../migrations/000013_normalise_target_countries.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string]string{}
+	for _, m := range regexp.MustCompile(`\('((?:[^']|'')*)', '([A-Z]{2})'\)`).FindAllStringSubmatch(string(data), -1) {
+		got[strings.ReplaceAll(m[1], "''", "'")] = m[2]
+	}
+
+	want := Aliases()
+	if len(got) != len(want) {
+		t.Errorf("migration maps %d aliases, dataset has %d; run go generate ./pkg/country", len(got), len(want))
+	}
+	for k, code := range want {
+		if got[k] != code {
+			t.Errorf("migration maps %q to %q, dataset maps it to %q; run go generate ./pkg/country", k, got[k], code)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"sca/pkg/country"
+)
+
+const header = `DROP TABLE IF EXISTS country_aliases;
+DROP PROCEDURE IF EXISTS check_target_countries;
+
+CREATE TABLE country_aliases
+(
+    alias VARCHAR(100) COLLATE utf8mb4_bin NOT NULL,
+    code  CHAR(2)                          NOT NULL,
+    PRIMARY KEY (alias)
+);
+
+INSERT INTO country_aliases (alias, code)
+VALUES `
+
+const footer = `;
+
+CREATE PROCEDURE check_target_countries()
+BEGIN
+    DECLARE unmatched INT;
+    SELECT COUNT(*)
+    INTO unmatched
+    FROM targets t
+             LEFT JOIN country_aliases a
+                       ON a.alias = UPPER(TRIM(REGEXP_REPLACE(REPLACE(t.country, '.', ''), '[[:space:]]+', ' '))) COLLATE utf8mb4_bin
+    WHERE a.alias IS NULL;
+    IF unmatched > 0 THEN
+        DROP TABLE country_aliases;
+        SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'Some targets have a country that is not in the ISO 3166 dataset; fix them and run the migration again';
+    END IF;
+END;
+
+CALL check_target_countries();
+DROP PROCEDURE check_target_countries;
+
+UPDATE targets t
+    JOIN country_aliases a
+    ON a.alias = UPPER(TRIM(REGEXP_REPLACE(REPLACE(t.country, '.', ''), '[[:space:]]+', ' '))) COLLATE utf8mb4_bin
+SET t.country = a.code;
+
+DROP TABLE country_aliases;`
+
+func main() {
+	output := flag.String("o", "", "write the migration to this file instead of stdout")
+	flag.Parse()
+
+	aliases := country.Aliases()
+	keys := make([]string, 0, len(aliases))
+	for k := range aliases {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if c := strings.Compare(aliases[a], aliases[b]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+
+	values := make([]string, len(keys))
+	for i, k := range keys {
+		values[i] = fmt.Sprintf("('%s', '%s')", strings.ReplaceAll(k, "'", "''"), aliases[k])
+	}
+	migration := header + strings.Join(values, ",\n       ") + footer
+
+	if *output == "" {
+		fmt.Print(migration)
+		return
+	}
+	if err := os.WriteFile(*output, []byte(migration), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -16,7 +16,7 @@ func Connect(username, password, host, database string) (*sqlx.DB, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	return sqlx.ConnectContext(ctx, "mysql", fmt.Sprintf("%s:%s@tcp(%s)/%s", username, password, host, database))
+	return sqlx.ConnectContext(ctx, "mysql", fmt.Sprintf("%s:%s@tcp(%s)/%s?parseTime=true", username, password, host, database))
 }
 
 func IsDuplicate(err error) bool {
@@ -0,0 +1,67 @@
+package diff
+
+import "strings"
+
+type Operation string
+
+const (
+	Equal  Operation = "equal"
+	Insert Operation = "insert"
+	Delete Operation = "delete"
+)
+
+type Change struct {
+	Op   Operation `json:"op"`
+	Text string    `json:"text"`
+}
+
+func Words(a, b string) []Change {
+	x, y := strings.Fields(a), strings.Fields(b)
+
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := []Change{}
+	push := func(op Operation, word string) {
+		if n := len(changes); n > 0 && changes[n-1].Op == op {
+			changes[n-1].Text += " " + word
+			return
+		}
+		changes = append(changes, Change{Op: op, Text: word})
+	}
+
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			push(Equal, x[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			push(Delete, x[i])
+			i++
+		default:
+			push(Insert, y[j])
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		push(Delete, x[i])
+	}
+	for ; j < len(y); j++ {
+		push(Insert, y[j])
+	}
+
+	return changes
+}
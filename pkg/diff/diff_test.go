@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want []Change
+	}{
+		{
+			name: "both empty",
+			a:    "",
+			b:    "",
+			want: []Change{},
+		},
+		{
+			name: "identical",
+			a:    "cat sits on mat",
+			b:    "cat sits on mat",
+			want: []Change{{Equal, "cat sits on mat"}},
+		},
+		{
+			name: "whitespace only differences",
+			a:    "cat  sits\non mat",
+			b:    " cat sits on\tmat ",
+			want: []Change{{Equal, "cat sits on mat"}},
+		},
+		{
+			name: "all inserted",
+			a:    "",
+			b:    "new notes",
+			want: []Change{{Insert, "new notes"}},
+		},
+		{
+			name: "all deleted",
+			a:    "old notes",
+			b:    "",
+			want: []Change{{Delete, "old notes"}},
+		},
+		{
+			name: "word replaced",
+			a:    "target seen in Paris",
+			b:    "target seen in Berlin",
+			want: []Change{{Equal, "target seen in"}, {Delete, "Paris"}, {Insert, "Berlin"}},
+		},
+		{
+			name: "words inserted in the middle",
+			a:    "target left hotel",
+			b:    "target quietly left the hotel",
+			want: []Change{{Equal, "target"}, {Insert, "quietly"}, {Equal, "left"}, {Insert, "the"}, {Equal, "hotel"}},
+		},
+		{
+			name: "words removed at both ends",
+			a:    "armed target left hotel today",
+			b:    "target left hotel",
+			want: []Change{{Delete, "armed"}, {Equal, "target left hotel"}, {Delete, "today"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Words(tt.a, tt.b)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWordsReconstructs(t *testing.T) {
+	a := "the cat watched the target leave the embassy at dawn"
+	b := "the cat followed the target from the embassy before dawn"
+
+	var before, after []string
+	for _, change := range Words(a, b) {
+		if change.Op != Insert {
+			before = append(before, change.Text)
+		}
+		if change.Op != Delete {
+			after = append(after, change.Text)
+		}
+	}
+
+	if got := strings.Join(before, " "); got != a {
+		t.Errorf("old side = %q, want %q", got, a)
+	}
+	if got := strings.Join(after, " "); got != b {
+		t.Errorf("new side = %q, want %q", got, b)
+	}
+}
@@ -18,6 +18,12 @@ func ErrorHandler(c fiber.Ctx, err error) error {
 	case ErrConflict:
 		code = fiber.StatusConflict
 		msg = e.Msg
+	case ErrBadRequest:
+		code = fiber.StatusBadRequest
+		msg = e.Msg
+	case ErrForbidden:
+		code = fiber.StatusForbidden
+		msg = e.Msg
 	}
 
 	return c.Status(code).JSON(&ErrorResponse{
@@ -15,3 +15,19 @@ type ErrConflict struct {
 func (e ErrConflict) Error() string {
 	return e.Msg
 }
+
+type ErrBadRequest struct {
+	Msg string
+}
+
+func (e ErrBadRequest) Error() string {
+	return e.Msg
+}
+
+type ErrForbidden struct {
+	Msg string
+}
+
+func (e ErrForbidden) Error() string {
+	return e.Msg
+}
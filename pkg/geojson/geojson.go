@@ -0,0 +1,36 @@
+package geojson
+
+const ContentType = "application/geo+json"
+
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+type Feature struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id,omitempty"`
+	Geometry   *Geometry      `json:"geometry"`
+	Properties map[string]any `json:"properties"`
+}
+
+type Geometry struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+}
+
+func NewFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{Type: "FeatureCollection", Features: []*Feature{}}
+}
+
+func NewPoint(id string, lng, lat float64, properties map[string]any) *Feature {
+	return &Feature{
+		Type: "Feature",
+		ID:   id,
+		Geometry: &Geometry{
+			Type:        "Point",
+			Coordinates: []float64{lng, lat},
+		},
+		Properties: properties,
+	}
+}
@@ -0,0 +1,200 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sca/pkg/errors"
+)
+
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidAmount    = errors.ErrBadRequest{Msg: "Invalid money amount: expected a decimal with at most 2 fraction digits"}
+	ErrInvalidCurrency  = errors.ErrBadRequest{Msg: "Invalid currency code: expected 3 uppercase letters"}
+	ErrCurrencyMismatch = errors.ErrConflict{Msg: "Currency mismatch"}
+)
+
+var (
+	amountPattern   = regexp.MustCompile(`^(-?)([0-9]+)(?:\.([0-9]{1,2}))?$`)
+	currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+type Amount int64
+
+func ParseAmount(s string) (Amount, error) {
+	m := amountPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, ErrInvalidAmount
+	}
+	negative, whole, frac := m[1] == "-", m[2], m[3]
+	frac += strings.Repeat("0", 2-len(frac))
+
+	units, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	cents, err := strconv.ParseInt(frac, 10, 64)
+	if err != nil || units > (math.MaxInt64-cents)/100 {
+		return 0, ErrInvalidAmount
+	}
+
+	amount := Amount(units*100 + cents)
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+func (a Amount) String() string {
+	sign := ""
+	if a < 0 {
+		sign = "-"
+		a = -a
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, a/100, a%100)
+}
+
+func (a Amount) Float64() float64 {
+	return float64(a) / 100
+}
+
+func (a Amount) MulDiv(num, den int64) Amount {
+	product := int64(a) * num
+	quotient, remainder := product/den, product%den
+	if 2*abs(remainder) >= abs(den) {
+		if (product < 0) != (den < 0) {
+			quotient--
+		} else {
+			quotient++
+		}
+	}
+	return Amount(quotient)
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func (a *Amount) Scan(src any) error {
+	switch v := src.(type) {
+	case []byte:
+		return a.parse(string(v))
+	case string:
+		return a.parse(v)
+	case int64:
+		*a = Amount(v * 100)
+		return nil
+	case nil:
+		*a = 0
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into money.Amount", src)
+	}
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if s, err := strconv.Unquote(string(data)); err == nil {
+		return a.parse(s)
+	}
+	return a.parse(string(data))
+}
+
+func (a *Amount) parse(s string) error {
+	amount, err := ParseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = amount
+	return nil
+}
+
+type Money struct {
+	Amount   Amount `json:"amount" db:"amount"`
+	Currency string `json:"currency" db:"currency"`
+}
+
+func New(amount Amount, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func Parse(amount, currency string) (Money, error) {
+	a, err := ParseAmount(amount)
+	if err != nil {
+		return Money{}, err
+	}
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	if !currencyPattern.MatchString(currency) {
+		return Money{}, ErrInvalidCurrency
+	}
+	return New(a, currency), nil
+}
+
+func (m Money) String() string {
+	return m.Amount.String() + " " + m.Currency
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return New(m.Amount+other.Amount, m.Currency), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.HasPrefix(data, []byte("{")) {
+		type plain Money
+		var p plain
+		if err := json.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		*m = Money(p)
+	} else {
+		if err := m.Amount.UnmarshalJSON(data); err != nil {
+			return err
+		}
+		m.Currency = ""
+	}
+
+	if m.Currency == "" {
+		m.Currency = DefaultCurrency
+	}
+	if !currencyPattern.MatchString(m.Currency) {
+		return ErrInvalidCurrency
+	}
+	return nil
+}
+
+func Accumulate(totals []Money, m Money) []Money {
+	for i, t := range totals {
+		if t.Currency == m.Currency {
+			totals[i].Amount += m.Amount
+			return totals
+		}
+	}
+	return append(totals, m)
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
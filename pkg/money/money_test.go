@@ -0,0 +1,214 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Amount
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "5", want: 500},
+		{in: "5.5", want: 550},
+		{in: "5.05", want: 505},
+		{in: "1234.56", want: 123456},
+		{in: "007.10", want: 710},
+		{in: " 12.30 ", want: 1230},
+		{in: "-3", want: -300},
+		{in: "-0.01", want: -1},
+		{in: "92233720368547758.07", want: math.MaxInt64},
+
+		{in: "", wantErr: true},
+		{in: "   ", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".5", wantErr: true},
+		{in: "1.", wantErr: true},
+		{in: "1.234", wantErr: true},
+		{in: "1.-5", wantErr: true},
+		{in: "1.+5", wantErr: true},
+		{in: "--3", wantErr: true},
+		{in: "+5", wantErr: true},
+		{in: "-+5", wantErr: true},
+		{in: "1..5", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "1,50", wantErr: true},
+		{in: "1e3", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "92233720368547758.08", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseAmount(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseAmount(%q) = %v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAmount(%q) returned error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAmount(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	tests := []struct {
+		in   Amount
+		want string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{550, "5.50"},
+		{-1, "-0.01"},
+		{-123456, "-1234.56"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.in.String(); got != tt.want {
+			t.Errorf("Amount(%d).String() = %q, want %q", tt.in, got, tt.want)
+		}
+		back, err := ParseAmount(tt.want)
+		if err != nil || back != tt.in {
+			t.Errorf("ParseAmount(%q) = %d, %v, want %d", tt.want, back, err, tt.in)
+		}
+	}
+}
+
+func TestAmountMulDiv(t *testing.T) {
+	tests := []struct {
+		amount   Amount
+		num, den int64
+		want     Amount
+	}{
+		{300000, 15, 30, 150000},
+		{100000, 10, 31, 32258},
+		{100, 1, 3, 33},
+		{100, 2, 3, 67},
+		{1, 1, 2, 1},
+		{-1, 1, 2, -1},
+		{-100, 2, 3, -67},
+		{100, 2, -3, -67},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.MulDiv(tt.num, tt.den); got != tt.want {
+			t.Errorf("Amount(%d).MulDiv(%d, %d) = %d, want %d", tt.amount, tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestAmountScan(t *testing.T) {
+	tests := []struct {
+		src     any
+		want    Amount
+		wantErr bool
+	}{
+		{src: []byte("12.34"), want: 1234},
+		{src: "0.50", want: 50},
+		{src: int64(7), want: 700},
+		{src: nil, want: 0},
+		{src: 1.5, wantErr: true},
+		{src: "1.-5", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Amount
+		err := got.Scan(tt.src)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Scan(%#v) error = %v, wantErr %v", tt.src, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("Scan(%#v) = %d, want %d", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		amount, currency string
+		want             Money
+		wantErr          error
+	}{
+		{amount: "10", currency: "", want: New(1000, DefaultCurrency)},
+		{amount: "10.5", currency: "EUR", want: New(1050, "EUR")},
+		{amount: "10", currency: "eur", wantErr: ErrInvalidCurrency},
+		{amount: "10", currency: "EURO", wantErr: ErrInvalidCurrency},
+		{amount: "+10", currency: "EUR", wantErr: ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		got, err := Parse(tt.amount, tt.currency)
+		if err != tt.wantErr {
+			t.Errorf("Parse(%q, %q) error = %v, want %v", tt.amount, tt.currency, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q, %q) = %v, want %v", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Money
+		wantErr bool
+	}{
+		{in: `"12.50"`, want: New(1250, DefaultCurrency)},
+		{in: `12.5`, want: New(1250, DefaultCurrency)},
+		{in: `{"amount":"3","currency":"GBP"}`, want: New(300, "GBP")},
+		{in: `{"amount":"3"}`, want: New(300, DefaultCurrency)},
+		{in: `{"amount":"3","currency":"gbp"}`, wantErr: true},
+		{in: `"1.-5"`, wantErr: true},
+		{in: `"+5"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var got Money
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Unmarshal(%s) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("Unmarshal(%s) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	data, err := json.Marshal(New(-1250, "EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), `{"amount":"-12.50","currency":"EUR"}`; got != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestAccumulate(t *testing.T) {
+	totals := []Money{}
+	totals = Accumulate(totals, New(100, "USD"))
+	totals = Accumulate(totals, New(250, "EUR"))
+	totals = Accumulate(totals, New(-50, "USD"))
+
+	want := []Money{New(50, "USD"), New(250, "EUR")}
+	if !reflect.DeepEqual(totals, want) {
+		t.Errorf("Accumulate() = %v, want %v", totals, want)
+	}
+
+	if _, err := New(100, "USD").Add(New(100, "EUR")); err != ErrCurrencyMismatch {
+		t.Errorf("Add() with different currencies error = %v, want %v", err, ErrCurrencyMismatch)
+	}
+}
@@ -0,0 +1,17 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+type LogNotifier struct{}
+
+func NewLogNotifier() *LogNotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Notify(_ context.Context, event Event) error {
+	log.Printf("[%s] %s", event.Type, event.Message)
+	return nil
+}
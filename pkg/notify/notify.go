@@ -0,0 +1,17 @@
+package notify
+
+import (
+	"context"
+	"time"
+)
+
+type Event struct {
+	Type       string    `json:"type"`
+	Message    string    `json:"message"`
+	Data       any       `json:"data"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
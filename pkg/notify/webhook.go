@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/client"
+)
+
+const webhookTimeout = 10 * time.Second
+
+type WebhookNotifier struct {
+	client *client.Client
+	url    string
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: client.New(),
+		url:    url,
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	resp, err := n.client.Post(n.url, client.Config{
+		Ctx:     ctx,
+		Body:    event,
+		Timeout: webhookTimeout,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	if resp.StatusCode() >= fiber.StatusBadRequest {
+		return fmt.Errorf("bad status: %s", resp.Status())
+	}
+	return nil
+}
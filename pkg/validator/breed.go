@@ -47,3 +47,18 @@ func breedValidator(fl validator.FieldLevel) bool {
 	}
 	return false
 }
+
+func BreedMatcher() (func(string) bool, error) {
+	breeds, err := getBreedsFunc()
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{}, len(breeds))
+	for _, b := range breeds {
+		names[b.Name] = struct{}{}
+	}
+	return func(breed string) bool {
+		_, ok := names[breed]
+		return ok
+	}, nil
+}
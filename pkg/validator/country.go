@@ -0,0 +1,12 @@
+package validator
+
+import (
+	"sca/pkg/country"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func countryValidator(fl validator.FieldLevel) bool {
+	_, ok := country.Lookup(fl.Field().String())
+	return ok
+}
@@ -0,0 +1,14 @@
+package validator
+
+import (
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var skillRegex = regexp.MustCompile(`^[a-z][a-z0-9]*([-_:][a-z0-9]+)*$`)
+
+func skillValidator(fl validator.FieldLevel) bool {
+	skill := fl.Field().String()
+	return len(skill) <= 32 && skillRegex.MatchString(skill)
+}
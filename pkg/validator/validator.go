@@ -1,9 +1,23 @@
 package validator
 
 import (
+	"reflect"
+
+	"sca/pkg/money"
+
 	"github.com/go-playground/validator/v10"
 )
 
 func RegisterValidators(v *validator.Validate) {
 	_ = v.RegisterValidation("breed", breedValidator)
+	_ = v.RegisterValidation("country", countryValidator)
+	_ = v.RegisterValidation("skill", skillValidator)
+	v.RegisterCustomTypeFunc(moneyValue, money.Money{})
+}
+
+func moneyValue(field reflect.Value) any {
+	if m, ok := field.Interface().(money.Money); ok {
+		return m.Amount.Float64()
+	}
+	return nil
 }